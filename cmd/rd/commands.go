@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+)
+
+// dateLayout is the format of the -start and -end flags of results
+const dateLayout = "2006-01-02"
+
+// newFlagSet creates a subcommand flag set with the shared flags registered
+func newFlagSet(env *environment, name, arguments string, global *globalFlags) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(env.stderr, "Usage: rd %s [flags] %s\n\nFlags:\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	global.register(flags)
+	return flags
+}
+
+// parseFlags parses the command line and checks the positional argument count (max < 0 means unlimited).
+// It returns ok=false with the exit code when the command should stop.
+func parseFlags(env *environment, flags *flag.FlagSet, global *globalFlags, args []string, min, max int) (code int, ok bool) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+
+	if !validFormat(global.output) {
+		fmt.Fprintf(env.stderr, "rd %s: invalid output format %q\n", flags.Name(), global.output)
+		return exitUsage, false
+	}
+
+	if n := flags.NArg(); n < min || (max >= 0 && n > max) {
+		flags.Usage()
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+// fail reports an error and returns its exit code
+func fail(env *environment, name string, err error) int {
+	fmt.Fprintf(env.stderr, "rd %s: %v\n", name, err)
+	return errorExitCode(err)
+}
+
+// document picks what JSON output prints: the single item for one argument, otherwise all of them
+func document[T any](items []T, many bool) any {
+	if !many && len(items) == 1 {
+		return items[0]
+	}
+	return items
+}
+
+// resultOptions builds polling options from the -interval and -max-attempts flags
+func resultOptions(interval time.Duration, maxAttempts int) *realitydefender.GetResultOptions {
+	return &realitydefender.GetResultOptions{
+		MaxAttempts: maxAttempts,
+		Interval:    interval,
+	}
+}
+
+// runUpload uploads each file and prints its request ID
+func runUpload(ctx context.Context, env *environment, args []string) int {
+	var global globalFlags
+	flags := newFlagSet(env, "upload", "<file>...", &global)
+	rejectMismatch := flags.Bool("reject-mismatch", false, "reject files whose content does not match their extension")
+	if code, ok := parseFlags(env, flags, &global, args, 1, -1); !ok {
+		return code
+	}
+
+	client, err := global.newClient(env)
+	if err != nil {
+		return fail(env, "upload", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+
+	code := exitOK
+	var rows []uploadRow
+	for _, path := range flags.Args() {
+		result, err := client.Upload(ctx, realitydefender.UploadOptions{
+			FilePath:           path,
+			RejectTypeMismatch: *rejectMismatch,
+		})
+		if err != nil {
+			code = worstExitCode(code, fail(env, "upload", fmt.Errorf("%s: %w", path, err)))
+			continue
+		}
+		rows = append(rows, uploadRow{Source: path, UploadResult: result})
+	}
+
+	if len(rows) == 0 {
+		return code
+	}
+	if err := render(env.stdout, global.output, document(rows, flags.NArg() > 1), rows, uploadColumns); err != nil {
+		return fail(env, "upload", err)
+	}
+	return code
+}
+
+// runDetect uploads each file and waits for its detection result
+func runDetect(ctx context.Context, env *environment, args []string) int {
+	var global globalFlags
+	flags := newFlagSet(env, "detect", "<file>...", &global)
+	rejectMismatch := flags.Bool("reject-mismatch", false, "reject files whose content does not match their extension")
+	interval := flags.Duration("interval", realitydefender.DefaultPollingInterval*time.Millisecond, "interval between result polls")
+	maxAttempts := flags.Int("max-attempts", 0, "maximum number of result polls (default the SDK default)")
+	if code, ok := parseFlags(env, flags, &global, args, 1, -1); !ok {
+		return code
+	}
+
+	client, err := global.newClient(env)
+	if err != nil {
+		return fail(env, "detect", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+
+	code := exitOK
+	var rows []detectRow
+	for _, path := range flags.Args() {
+		upload, err := client.Upload(ctx, realitydefender.UploadOptions{
+			FilePath:           path,
+			RejectTypeMismatch: *rejectMismatch,
+		})
+		if err != nil {
+			code = worstExitCode(code, fail(env, "detect", fmt.Errorf("%s: %w", path, err)))
+			continue
+		}
+
+		result, err := client.GetResult(ctx, upload.RequestID, resultOptions(*interval, *maxAttempts))
+		if err != nil {
+			code = worstExitCode(code, fail(env, "detect", fmt.Errorf("%s: %w", path, err)))
+			continue
+		}
+		rows = append(rows, detectRow{Source: path, DetectionResult: result})
+		code = worstExitCode(code, statusExitCode(result.Status))
+	}
+
+	if len(rows) == 0 {
+		return code
+	}
+	if err := render(env.stdout, global.output, document(rows, flags.NArg() > 1), rows, detectColumns); err != nil {
+		return fail(env, "detect", err)
+	}
+	return code
+}
+
+// runResult prints the detection result for a request ID
+func runResult(ctx context.Context, env *environment, args []string) int {
+	var global globalFlags
+	flags := newFlagSet(env, "result", "<request-id>", &global)
+	interval := flags.Duration("interval", realitydefender.DefaultPollingInterval*time.Millisecond, "interval between result polls")
+	maxAttempts := flags.Int("max-attempts", 0, "maximum number of result polls (default the SDK default)")
+	if code, ok := parseFlags(env, flags, &global, args, 1, 1); !ok {
+		return code
+	}
+
+	client, err := global.newClient(env)
+	if err != nil {
+		return fail(env, "result", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+
+	result, err := client.GetResult(ctx, flags.Arg(0), resultOptions(*interval, *maxAttempts))
+	if err != nil {
+		return fail(env, "result", err)
+	}
+
+	if err := render(env.stdout, global.output, result, []realitydefender.DetectionResult{*result}, resultColumns); err != nil {
+		return fail(env, "result", err)
+	}
+	return statusExitCode(result.Status)
+}
+
+// runResults lists detection results with the filters of GetResults
+func runResults(ctx context.Context, env *environment, args []string) int {
+	var global globalFlags
+	flags := newFlagSet(env, "results", "", &global)
+	page := flags.Int("page", 0, "page number, starting at 0")
+	size := flags.Int("size", 10, "page size")
+	name := flags.String("name", "", "only results whose name contains this text")
+	start := flags.String("start", "", "only results created on or after this date (YYYY-MM-DD)")
+	end := flags.String("end", "", "only results created on or before this date (YYYY-MM-DD)")
+	if code, ok := parseFlags(env, flags, &global, args, 0, 0); !ok {
+		return code
+	}
+
+	var nameArg *string
+	if *name != "" {
+		nameArg = name
+	}
+	startDate, err := parseDate(*start)
+	if err != nil {
+		fmt.Fprintf(env.stderr, "rd results: invalid -start: %v\n", err)
+		return exitUsage
+	}
+	endDate, err := parseDate(*end)
+	if err != nil {
+		fmt.Fprintf(env.stderr, "rd results: invalid -end: %v\n", err)
+		return exitUsage
+	}
+
+	client, err := global.newClient(env)
+	if err != nil {
+		return fail(env, "results", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+
+	list, err := client.GetResults(ctx, page, size, nameArg, startDate, endDate, nil)
+	if err != nil {
+		return fail(env, "results", err)
+	}
+
+	if err := render(env.stdout, global.output, list, list.Items, resultColumns); err != nil {
+		return fail(env, "results", err)
+	}
+	return exitOK
+}
+
+// parseDate parses an optional date flag
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// runSocial submits a social media link and prints its request ID
+func runSocial(ctx context.Context, env *environment, args []string) int {
+	var global globalFlags
+	flags := newFlagSet(env, "social", "<url>", &global)
+	if code, ok := parseFlags(env, flags, &global, args, 1, 1); !ok {
+		return code
+	}
+
+	client, err := global.newClient(env)
+	if err != nil {
+		return fail(env, "social", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+
+	result, err := client.UploadSocialMedia(ctx, realitydefender.UploadSocialMediaOptions{
+		SocialLink: flags.Arg(0),
+	})
+	if err != nil {
+		return fail(env, "social", err)
+	}
+
+	row := uploadRow{Source: flags.Arg(0), UploadResult: result}
+	if err := render(env.stdout, global.output, row, []uploadRow{row}, uploadColumns); err != nil {
+		return fail(env, "social", err)
+	}
+	return exitOK
+}
+
+// runFeedback submits feedback on a detection result
+func runFeedback(ctx context.Context, env *environment, args []string) int {
+	var global globalFlags
+	flags := newFlagSet(env, "feedback", "[request-id]", &global)
+	requestID := flags.String("request-id", "", "request ID of the detection result (or the first argument)")
+	label := flags.String("label", "", "content judgment: REAL, SYNTHETIC, MANIPULATED or UNKNOWN")
+	category := flags.String("category", "", "FALSE_POSITIVE, FALSE_NEGATIVE, CONFIRMATION or OTHER")
+	comment := flags.String("comment", "", "optional free text")
+	if code, ok := parseFlags(env, flags, &global, args, 0, 1); !ok {
+		return code
+	}
+
+	options := realitydefender.CreateUserFeedbackOptions{
+		RequestID:        firstNonEmpty(*requestID, flags.Arg(0)),
+		Label:            *label,
+		FeedbackCategory: *category,
+	}
+	if *comment != "" {
+		options.Comment = comment
+	}
+
+	client, err := global.newClient(env)
+	if err != nil {
+		return fail(env, "feedback", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+
+	feedback, err := client.CreateUserFeedback(ctx, options)
+	if err != nil {
+		return fail(env, "feedback", err)
+	}
+
+	if err := render(env.stdout, global.output, feedback, []*realitydefender.UserFeedback{feedback}, feedbackColumns); err != nil {
+		return fail(env, "feedback", err)
+	}
+	return exitOK
+}
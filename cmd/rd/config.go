@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+)
+
+// Environment variables read by rd
+const (
+	envAPIKey  = "REALITY_DEFENDER_API_KEY"
+	envBaseURL = "REALITY_DEFENDER_BASE_URL"
+	envConfig  = "REALITY_DEFENDER_CONFIG"
+)
+
+// fileConfig is the JSON config file format
+type fileConfig struct {
+	APIKey  string `json:"api_key"`
+	BaseURL string `json:"base_url"`
+}
+
+// globalFlags are the flags shared by every subcommand
+type globalFlags struct {
+	apiKey     string
+	baseURL    string
+	configPath string
+	output     string
+	timeout    time.Duration
+}
+
+// register adds the shared flags to a subcommand's flag set
+func (g *globalFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&g.apiKey, "api-key", "", "API key (default $"+envAPIKey+" or the config file)")
+	flags.StringVar(&g.baseURL, "base-url", "", "API base URL (default $"+envBaseURL+", the config file or "+realitydefender.DefaultBaseURL+")")
+	flags.StringVar(&g.configPath, "config", "", "config file path (default $"+envConfig+" or "+filepath.Join("<user config dir>", "rd", "config.json")+")")
+	flags.StringVar(&g.output, "o", formatJSON, "output format: json, table or ndjson")
+	flags.DurationVar(&g.timeout, "timeout", 10*time.Minute, "overall timeout for the command")
+}
+
+// newClient resolves the API key and base URL and creates a client.
+// Flags take precedence over environment variables, which take precedence over the config file.
+func (g *globalFlags) newClient(env *environment) (*realitydefender.Client, error) {
+	config, err := g.loadConfig(env)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := firstNonEmpty(g.apiKey, env.getenv(envAPIKey), config.APIKey)
+	if apiKey == "" {
+		return nil, &realitydefender.SDKError{
+			Message: "API key is required: use -api-key, $" + envAPIKey + " or the config file",
+			Code:    realitydefender.ErrorCodeUnauthorized,
+		}
+	}
+
+	return realitydefender.New(realitydefender.Config{
+		APIKey:  apiKey,
+		BaseURL: firstNonEmpty(g.baseURL, env.getenv(envBaseURL), config.BaseURL),
+	})
+}
+
+// loadConfig reads the config file. A missing file at the default location is not an error.
+func (g *globalFlags) loadConfig(env *environment) (fileConfig, error) {
+	var config fileConfig
+
+	path := firstNonEmpty(g.configPath, env.getenv(envConfig))
+	explicit := path != ""
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return config, nil
+		}
+		path = filepath.Join(dir, "rd", "config.json")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return config, nil
+		}
+		return config, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
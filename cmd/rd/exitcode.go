@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+)
+
+// Exit codes. Errors map to 2-9 by ErrorCode; detection statuses map to 10 and above.
+const (
+	exitOK            = 0
+	exitError         = 1 // unexpected error
+	exitUsage         = 2 // invalid command line
+	exitUnauthorized  = 3
+	exitInvalidInput  = 4 // invalid request, invalid file or file too large
+	exitNotFound      = 5
+	exitRateLimited   = 6
+	exitTimeout       = 7
+	exitServerError   = 8 // server error or failed upload
+	exitManipulated   = 10
+	exitInconclusive  = 11 // still analyzing, suspicious or not evaluable
+	exitUnknownStatus = 12
+	exitAnalysisError = 13 // the analysis of the media failed
+)
+
+// errorExitCode maps an error to an exit code by its ErrorCode
+func errorExitCode(err error) int {
+	var sdkErr *realitydefender.SDKError
+	if !errors.As(err, &sdkErr) {
+		return exitError
+	}
+
+	switch sdkErr.Code {
+	case realitydefender.ErrorCodeUnauthorized:
+		return exitUnauthorized
+	case realitydefender.ErrorCodeInvalidRequest, realitydefender.ErrorCodeInvalidFile, realitydefender.ErrorCodeFileTooLarge:
+		return exitInvalidInput
+	case realitydefender.ErrorCodeNotFound:
+		return exitNotFound
+	case realitydefender.ErrorCodeRateLimited:
+		return exitRateLimited
+	case realitydefender.ErrorCodeTimeout:
+		return exitTimeout
+	case realitydefender.ErrorCodeServerError, realitydefender.ErrorCodeUploadFailed:
+		return exitServerError
+	default:
+		return exitError
+	}
+}
+
+// statusExitCode maps a detection status to an exit code
+func statusExitCode(status realitydefender.Status) int {
+	switch status {
+	case realitydefender.StatusAuthentic:
+		return exitOK
+	case realitydefender.StatusManipulated:
+		return exitManipulated
+	case realitydefender.StatusAnalyzing, realitydefender.StatusSuspicious, realitydefender.StatusNotApplicable, realitydefender.StatusUnableToEvaluate:
+		return exitInconclusive
+	case realitydefender.StatusError:
+		return exitAnalysisError
+	default:
+		return exitUnknownStatus
+	}
+}
+
+// worstExitCode combines exit codes from several items: errors, including failed
+// analyses, win over manipulated results, which win over inconclusive ones
+func worstExitCode(a, b int) int {
+	rank := func(code int) int {
+		switch code {
+		case exitOK:
+			return 0
+		case exitUnknownStatus:
+			return 1
+		case exitInconclusive:
+			return 2
+		case exitManipulated:
+			return 3
+		default:
+			return 4
+		}
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
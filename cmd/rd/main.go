@@ -0,0 +1,97 @@
+// Command rd is a command-line client for the Reality Defender API.
+//
+// Usage:
+//
+//	rd <command> [flags] [arguments]
+//
+// Commands:
+//
+//	upload    Upload media files for analysis and print their request IDs
+//	detect    Upload media files and wait for their detection results
+//	result    Get the detection result for a request ID
+//	results   List detection results
+//	social    Submit a social media link for analysis
+//	feedback  Submit feedback on a detection result
+//
+// The API key is read from the -api-key flag, the REALITY_DEFENDER_API_KEY
+// environment variable or the config file, in that order.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// command is an rd subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, env *environment, args []string) int
+}
+
+// commands lists the subcommands in the order they are shown in help output
+var commands = []command{
+	{"upload", "Upload media files for analysis and print their request IDs", runUpload},
+	{"detect", "Upload media files and wait for their detection results", runDetect},
+	{"result", "Get the detection result for a request ID", runResult},
+	{"results", "List detection results", runResults},
+	{"social", "Submit a social media link for analysis", runSocial},
+	{"feedback", "Submit feedback on a detection result", runFeedback},
+}
+
+// environment holds the process state a command needs, so commands can run in tests
+type environment struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], &environment{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	})
+	stop()
+	os.Exit(code)
+}
+
+// run dispatches to the subcommand named by the first argument and returns the exit code
+func run(ctx context.Context, args []string, env *environment) int {
+	if len(args) == 0 {
+		usage(env.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(env.stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, env, args[1:])
+		}
+	}
+
+	fmt.Fprintf(env.stderr, "rd: unknown command %q\n\n", args[0])
+	usage(env.stderr)
+	return exitUsage
+}
+
+// usage prints the list of subcommands
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: rd <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s%s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "rd <command> -h" for the flags of a command.`)
+}
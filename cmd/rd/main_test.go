@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rd", func() {
+	var (
+		server  *httptest.Server
+		vars    map[string]string
+		stdout  *bytes.Buffer
+		stderr  *bytes.Buffer
+		status  string
+		apiKeys []string
+		query   string
+		dir     string
+	)
+
+	BeforeEach(func() {
+		status = "FAKE"
+		apiKeys = nil
+		dir = GinkgoT().TempDir()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKeys = append(apiKeys, r.Header.Get("X-API-KEY"))
+			switch {
+			case r.URL.Path == "/api/files/aws-presigned":
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"response":{"signedUrl":"http://` + r.Host + `/upload"},"mediaId":"media-1","requestId":"req-1"}`))
+			case r.URL.Path == "/upload":
+				w.WriteHeader(http.StatusOK)
+			case r.URL.Path == "/api/media/users/req-1":
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"requestId":"req-1","resultsSummary":{"status":"` + status + `","metadata":{"finalScore":95}},"models":[]}`))
+			case strings.HasPrefix(r.URL.Path, "/api/v2/media/users/pages/"):
+				query = r.URL.RawQuery
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"totalItems":2,"totalPages":1,"currentPage":0,"currentPageItemsCount":2,"mediaList":[` +
+					`{"requestId":"req-1","resultsSummary":{"status":"AUTHENTIC"}},{"requestId":"req-2","resultsSummary":{"status":"FAKE"}}]}`))
+			case r.URL.Path == "/api/files/social":
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"requestId":"req-social"}`))
+			case r.URL.Path == "/api/v2/user-feedback":
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":"unauthorized","response":"Invalid API key"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":"not-found","response":"Not found"}`))
+			}
+		}))
+
+		emptyConfig := filepath.Join(dir, "empty.json")
+		Expect(os.WriteFile(emptyConfig, []byte(`{}`), 0o600)).To(Succeed())
+		vars = map[string]string{
+			envAPIKey:  "env-key",
+			envBaseURL: server.URL,
+			envConfig:  emptyConfig,
+		}
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	rd := func(args ...string) int {
+		return run(context.Background(), args, &environment{
+			stdout: stdout,
+			stderr: stderr,
+			getenv: func(key string) string { return vars[key] },
+		})
+	}
+
+	writeMedia := func(name string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte("content"), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("prints usage and fails on unknown commands", func() {
+		Expect(rd()).To(Equal(exitUsage))
+		Expect(rd("bogus")).To(Equal(exitUsage))
+		Expect(stderr.String()).To(ContainSubstring(`unknown command "bogus"`))
+		Expect(rd("help")).To(Equal(exitOK))
+		Expect(stdout.String()).To(ContainSubstring("detect"))
+	})
+
+	Describe("detect", func() {
+		It("prints the result as JSON and exits with the status code", func() {
+			path := writeMedia("clip.txt")
+
+			Expect(rd("detect", "-interval", "1ms", path)).To(Equal(exitManipulated))
+
+			var out map[string]any
+			Expect(json.Unmarshal(stdout.Bytes(), &out)).To(Succeed())
+			Expect(out["source"]).To(Equal(path))
+			Expect(out["requestId"]).To(Equal("req-1"))
+			Expect(out["status"]).To(Equal("MANIPULATED"))
+		})
+
+		It("prints one line per file as NDJSON", func() {
+			status = "AUTHENTIC"
+			first, second := writeMedia("a.txt"), writeMedia("b.txt")
+
+			Expect(rd("detect", "-o", "ndjson", first, second)).To(Equal(exitOK))
+
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[1]).To(ContainSubstring(`"source":"` + second + `"`))
+		})
+
+		It("reports per-file errors and keeps going", func() {
+			path := writeMedia("clip.txt")
+
+			code := rd("detect", "-o", "table", filepath.Join(dir, "missing.txt"), path)
+			Expect(code).To(Equal(exitInvalidInput))
+			Expect(stderr.String()).To(ContainSubstring("missing.txt"))
+			Expect(stdout.String()).To(ContainSubstring("MANIPULATED"))
+			Expect(stdout.String()).To(ContainSubstring("0.9500"))
+		})
+	})
+
+	Describe("result", func() {
+		It("maps API errors to exit codes", func() {
+			Expect(rd("result", "-max-attempts", "1", "unknown")).To(Equal(exitNotFound))
+			Expect(stderr.String()).To(ContainSubstring("rd result:"))
+		})
+
+		It("prints a table", func() {
+			status = "ANALYZING"
+			Expect(rd("result", "-o", "table", "-max-attempts", "1", "req-1")).To(Equal(exitInconclusive))
+			Expect(stdout.String()).To(ContainSubstring("REQUEST ID"))
+			Expect(stdout.String()).To(ContainSubstring("ANALYZING"))
+		})
+
+		It("maps a failed analysis to its own exit code", func() {
+			status = "ERROR"
+			Expect(rd("result", "-max-attempts", "1", "req-1")).To(Equal(exitAnalysisError))
+		})
+	})
+
+	Describe("results", func() {
+		It("passes filters and lists the page", func() {
+			code := rd("results", "-o", "ndjson", "-size", "2", "-name", "clip", "-start", "2024-01-01", "-end", "2024-02-01")
+			Expect(code).To(Equal(exitOK))
+			Expect(query).To(ContainSubstring("size=2"))
+			Expect(query).To(ContainSubstring("name=clip"))
+			Expect(query).To(ContainSubstring("startDate=2024-01-01"))
+			Expect(query).To(ContainSubstring("endDate=2024-02-01"))
+			Expect(strings.Split(strings.TrimSpace(stdout.String()), "\n")).To(HaveLen(2))
+		})
+
+		It("rejects invalid dates", func() {
+			Expect(rd("results", "-start", "yesterday")).To(Equal(exitUsage))
+		})
+	})
+
+	It("submits social media links", func() {
+		Expect(rd("social", "https://twitter.com/example")).To(Equal(exitOK))
+		Expect(stdout.String()).To(ContainSubstring("req-social"))
+	})
+
+	It("maps rejected feedback to the unauthorized exit code", func() {
+		code := rd("feedback", "-label", "REAL", "-category", "CONFIRMATION", "req-1")
+		Expect(code).To(Equal(exitUnauthorized))
+	})
+
+	Describe("API key resolution", func() {
+		It("prefers the flag over the environment", func() {
+			Expect(rd("social", "-api-key", "flag-key", "https://twitter.com/example")).To(Equal(exitOK))
+			Expect(apiKeys).To(Equal([]string{"flag-key"}))
+		})
+
+		It("falls back to the config file", func() {
+			config := filepath.Join(dir, "config.json")
+			Expect(os.WriteFile(config, []byte(`{"api_key":"file-key"}`), 0o600)).To(Succeed())
+			delete(vars, envAPIKey)
+
+			Expect(rd("social", "-config", config, "https://twitter.com/example")).To(Equal(exitOK))
+			Expect(apiKeys).To(Equal([]string{"file-key"}))
+		})
+
+		It("fails without an API key", func() {
+			delete(vars, envAPIKey)
+			Expect(rd("social", "https://twitter.com/example")).To(Equal(exitUnauthorized))
+			Expect(stderr.String()).To(ContainSubstring("API key is required"))
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+)
+
+// Output formats
+const (
+	formatJSON   = "json"
+	formatTable  = "table"
+	formatNDJSON = "ndjson"
+)
+
+// column is a table column with a function extracting its value from an item
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// validFormat reports whether format is a supported output format
+func validFormat(format string) bool {
+	return format == formatJSON || format == formatTable || format == formatNDJSON
+}
+
+// render writes the output of a command. JSON prints doc as one indented document,
+// NDJSON prints one compact line per item and table prints the items as rows.
+func render[T any](w io.Writer, format string, doc any, items []T, columns []column[T]) error {
+	switch format {
+	case formatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		names := make([]string, len(columns))
+		for i, col := range columns {
+			names[i] = col.name
+		}
+		fmt.Fprintln(tw, strings.Join(names, "\t"))
+		for _, item := range items {
+			values := make([]string, len(columns))
+			for i, col := range columns {
+				values[i] = col.value(item)
+			}
+			fmt.Fprintln(tw, strings.Join(values, "\t"))
+		}
+		return tw.Flush()
+	default:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	}
+}
+
+// formatScore formats an optional score for tables
+func formatScore(score *float64) string {
+	if score == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*score, 'f', 4, 64)
+}
+
+// uploadRow is an upload result labeled with its source
+type uploadRow struct {
+	Source string `json:"source"`
+	*realitydefender.UploadResult
+}
+
+// uploadColumns are the table columns for upload results
+var uploadColumns = []column[uploadRow]{
+	{"SOURCE", func(r uploadRow) string { return r.Source }},
+	{"REQUEST ID", func(r uploadRow) string { return r.RequestID }},
+	{"MEDIA ID", func(r uploadRow) string { return r.MediaID }},
+}
+
+// resultColumns are the table columns for detection results
+var resultColumns = []column[realitydefender.DetectionResult]{
+	{"REQUEST ID", func(r realitydefender.DetectionResult) string { return r.RequestID }},
+	{"STATUS", func(r realitydefender.DetectionResult) string { return string(r.Status) }},
+	{"SCORE", func(r realitydefender.DetectionResult) string { return formatScore(r.Score) }},
+	{"MODELS", func(r realitydefender.DetectionResult) string { return strconv.Itoa(len(r.Models)) }},
+}
+
+// detectRow is a detection result labeled with its source file
+type detectRow struct {
+	Source string `json:"source"`
+	*realitydefender.DetectionResult
+}
+
+// detectColumns are the table columns for detect output
+var detectColumns = []column[detectRow]{
+	{"SOURCE", func(r detectRow) string { return r.Source }},
+	{"REQUEST ID", func(r detectRow) string { return r.RequestID }},
+	{"STATUS", func(r detectRow) string { return string(r.Status) }},
+	{"SCORE", func(r detectRow) string { return formatScore(r.Score) }},
+}
+
+// feedbackColumns are the table columns for submitted feedback
+var feedbackColumns = []column[*realitydefender.UserFeedback]{
+	{"ID", func(f *realitydefender.UserFeedback) string { return f.ID }},
+	{"REQUEST ID", func(f *realitydefender.UserFeedback) string { return f.RequestID }},
+	{"LABEL", func(f *realitydefender.UserFeedback) string { return f.Label }},
+	{"CATEGORY", func(f *realitydefender.UserFeedback) string { return f.Category }},
+	{"CREATED", func(f *realitydefender.UserFeedback) string { return f.CreatedAt }},
+}
@@ -85,7 +85,7 @@ func main() {
 
 	// Use GetResult with a longer polling interval for better performance
 	result, err := client.GetResult(ctx, uploadResult.RequestID, &realitydefender.GetResultOptions{
-		PollingInterval: 3000, // 3 seconds between polls
+		Interval: 3 * time.Second, // 3 seconds between polls
 	})
 
 	// Stop the progress indicator
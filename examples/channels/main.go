@@ -83,7 +83,7 @@ func analyzeWithChannels(ctx context.Context, filePath string, apiKey string) {
 				}
 
 				// If still analyzing, wait and try again
-				if result.Status == "ANALYZING" {
+				if !result.IsFinal() {
 					waitTime := time.Duration(baseWait*(attempt+1)) * time.Millisecond
 					time.Sleep(waitTime)
 					attempt++
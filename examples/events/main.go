@@ -45,9 +45,7 @@ func main() {
 	wg.Add(1)
 
 	// Set up event handlers
-	client.On("result", func(data interface{}) {
-		result := data.(*realitydefender.DetectionResult)
-
+	client.OnResult(func(result *realitydefender.DetectionResult) {
 		fmt.Println("\nResult received from event:")
 		fmt.Printf("Status: %s\n", result.Status)
 		fmt.Printf("Score: %s\n", formatScore(result.Score))
@@ -64,8 +62,7 @@ func main() {
 		wg.Done()
 	})
 
-	client.On("error", func(data interface{}) {
-		err := data.(error)
+	client.OnError(func(err error) {
 		fmt.Printf("\nError received from event: %v\n", err)
 		wg.Done()
 	})
@@ -93,8 +90,8 @@ func main() {
 	// Start polling using the event-based approach
 	fmt.Println("\nStarting event-based polling...")
 	err = client.PollForResults(ctx, uploadResult.RequestID, &realitydefender.PollOptions{
-		PollingInterval: 2000,  // 2 seconds between polls
-		Timeout:         60000, // 60 second timeout
+		Interval:    2 * time.Second,  // 2 seconds between polls
+		PollTimeout: 60 * time.Second, // 60 second timeout
 	})
 	if err != nil {
 		fmt.Printf("Polling failed: %v\n", err)
@@ -7,3 +7,12 @@ toolchain go1.23.1
 replace github.com/Reality-Defender/realitydefender-sdk-go => ../
 
 require github.com/Reality-Defender/realitydefender-sdk-go v0.0.0-00010101000000-000000000000
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel v1.35.0 // indirect
+	go.opentelemetry.io/otel/metric v1.35.0 // indirect
+	go.opentelemetry.io/otel/trace v1.35.0 // indirect
+)
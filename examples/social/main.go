@@ -109,8 +109,8 @@ func main() {
 
 	// Poll for results with custom options
 	result, err := client.GetResult(ctx, uploadResult.RequestID, &realitydefender.GetResultOptions{
-		PollingInterval: 3000, // 3 seconds between polls
-		MaxAttempts:     60,   // Maximum 60 attempts (3 minutes)
+		Interval:    3 * time.Second, // 3 seconds between polls
+		MaxAttempts: 60,              // Maximum 60 attempts (3 minutes)
 	})
 
 	// Stop the progress indicator
@@ -142,11 +142,11 @@ func main() {
 	// Interpret the results
 	fmt.Println("\nInterpretation:")
 	switch result.Status {
-	case "MANIPULATED":
+	case realitydefender.StatusManipulated:
 		fmt.Println("⚠️  This content appears to be artificially generated or manipulated.")
-	case "AUTHENTIC":
+	case realitydefender.StatusAuthentic:
 		fmt.Println("✅ This content appears to be authentic (not artificially generated).")
-	case "ANALYZING":
+	case realitydefender.StatusAnalyzing:
 		fmt.Println("⏳ Analysis is still in progress. Some models may still be processing.")
 	default:
 		fmt.Printf("🔍 Status: %s\n", result.Status)
@@ -165,13 +165,13 @@ func main() {
 
 			// Add interpretation for each model
 			switch model.Status {
-			case "MANIPULATED":
+			case realitydefender.StatusManipulated:
 				fmt.Println("   💡 This model detected signs of artificial generation/manipulation")
-			case "AUTHENTIC":
+			case realitydefender.StatusAuthentic:
 				fmt.Println("   💡 This model found no signs of manipulation")
-			case "NOT_APPLICABLE":
+			case realitydefender.StatusNotApplicable:
 				fmt.Println("   💡 This model is not applicable to this type of content")
-			case "ANALYZING":
+			case realitydefender.StatusAnalyzing:
 				fmt.Println("   💡 This model is still processing the content")
 			}
 			fmt.Println()
@@ -0,0 +1,166 @@
+package realitydefender
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Uploader submits media for analysis
+type Uploader interface {
+	// Upload uploads a file for analysis
+	Upload(ctx context.Context, options UploadOptions) (*UploadResult, error)
+	// UploadSocialMedia submits a social media link for analysis
+	UploadSocialMedia(ctx context.Context, options UploadSocialMediaOptions) (*UploadResult, error)
+}
+
+// ResultGetter retrieves detection results
+type ResultGetter interface {
+	// GetResult gets the detection result of a request, waiting while it is analyzed
+	GetResult(ctx context.Context, requestID string, options *GetResultOptions) (*DetectionResult, error)
+	// GetResults gets a page of detection results
+	GetResults(ctx context.Context, pageNumber *int, size *int, name *string, startDate *time.Time, endDate *time.Time, options *GetResultOptions) (*DetectionResultList, error)
+	// PollForResults polls for the result of a request, delivering it as events
+	PollForResults(ctx context.Context, requestID string, options *PollOptions) error
+}
+
+// Detector uploads a file and waits for its result
+type Detector interface {
+	// DetectFile uploads a file and returns its detection result
+	DetectFile(ctx context.Context, filePath string) (*DetectionResult, error)
+}
+
+// FeedbackSubmitter submits feedback on detection results
+type FeedbackSubmitter interface {
+	// CreateUserFeedback submits feedback on a detection result
+	CreateUserFeedback(ctx context.Context, opts CreateUserFeedbackOptions) (*UserFeedback, error)
+}
+
+// API is the Reality Defender API as used through the SDK. *Client implements it; accept
+// the smaller interfaces where possible so that tests can substitute a fake.
+type API interface {
+	Uploader
+	ResultGetter
+	Detector
+	FeedbackSubmitter
+}
+
+var _ API = (*Client)(nil)
+
+// API method names reported in Call
+const (
+	MethodUpload             = "Upload"
+	MethodUploadSocialMedia  = "UploadSocialMedia"
+	MethodGetResult          = "GetResult"
+	MethodGetResults         = "GetResults"
+	MethodPollForResults     = "PollForResults"
+	MethodDetectFile         = "DetectFile"
+	MethodCreateUserFeedback = "CreateUserFeedback"
+)
+
+// Call describes a call of an API method
+type Call struct {
+	// Method is the method called, one of the Method constants
+	Method string
+	// Args holds the arguments after the context, in order
+	Args []interface{}
+}
+
+// Interceptor runs around a call of an API method, for logging, metrics or caching. It calls
+// invoke to make the call and returns its result, or returns a result of its own. A result
+// of its own must have the method's result type, such as *DetectionResult for GetResult, or
+// be nil; PollForResults has no result.
+type Interceptor func(ctx context.Context, call Call, invoke func(ctx context.Context) (interface{}, error)) (interface{}, error)
+
+// Decorate wraps an API so that every call runs through the interceptors. The first
+// interceptor is the outermost.
+func Decorate(api API, interceptors ...Interceptor) API {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		if interceptors[i] != nil {
+			api = &decorated{next: api, intercept: interceptors[i]}
+		}
+	}
+	return api
+}
+
+// decorated is an API that runs its calls through an Interceptor
+type decorated struct {
+	next      API
+	intercept Interceptor
+}
+
+// Upload implements Uploader
+func (d *decorated) Upload(ctx context.Context, options UploadOptions) (*UploadResult, error) {
+	out, err := d.intercept(ctx, Call{Method: MethodUpload, Args: []interface{}{options}}, func(ctx context.Context) (interface{}, error) {
+		return d.next.Upload(ctx, options)
+	})
+	return interceptedResult[UploadResult](MethodUpload, out, err)
+}
+
+// UploadSocialMedia implements Uploader
+func (d *decorated) UploadSocialMedia(ctx context.Context, options UploadSocialMediaOptions) (*UploadResult, error) {
+	out, err := d.intercept(ctx, Call{Method: MethodUploadSocialMedia, Args: []interface{}{options}}, func(ctx context.Context) (interface{}, error) {
+		return d.next.UploadSocialMedia(ctx, options)
+	})
+	return interceptedResult[UploadResult](MethodUploadSocialMedia, out, err)
+}
+
+// GetResult implements ResultGetter
+func (d *decorated) GetResult(ctx context.Context, requestID string, options *GetResultOptions) (*DetectionResult, error) {
+	out, err := d.intercept(ctx, Call{Method: MethodGetResult, Args: []interface{}{requestID, options}}, func(ctx context.Context) (interface{}, error) {
+		return d.next.GetResult(ctx, requestID, options)
+	})
+	return interceptedResult[DetectionResult](MethodGetResult, out, err)
+}
+
+// GetResults implements ResultGetter
+func (d *decorated) GetResults(ctx context.Context, pageNumber *int, size *int, name *string, startDate *time.Time, endDate *time.Time, options *GetResultOptions) (*DetectionResultList, error) {
+	call := Call{Method: MethodGetResults, Args: []interface{}{pageNumber, size, name, startDate, endDate, options}}
+	out, err := d.intercept(ctx, call, func(ctx context.Context) (interface{}, error) {
+		return d.next.GetResults(ctx, pageNumber, size, name, startDate, endDate, options)
+	})
+	return interceptedResult[DetectionResultList](MethodGetResults, out, err)
+}
+
+// PollForResults implements ResultGetter
+func (d *decorated) PollForResults(ctx context.Context, requestID string, options *PollOptions) error {
+	_, err := d.intercept(ctx, Call{Method: MethodPollForResults, Args: []interface{}{requestID, options}}, func(ctx context.Context) (interface{}, error) {
+		return nil, d.next.PollForResults(ctx, requestID, options)
+	})
+	return err
+}
+
+// DetectFile implements Detector
+func (d *decorated) DetectFile(ctx context.Context, filePath string) (*DetectionResult, error) {
+	out, err := d.intercept(ctx, Call{Method: MethodDetectFile, Args: []interface{}{filePath}}, func(ctx context.Context) (interface{}, error) {
+		return d.next.DetectFile(ctx, filePath)
+	})
+	return interceptedResult[DetectionResult](MethodDetectFile, out, err)
+}
+
+// CreateUserFeedback implements FeedbackSubmitter
+func (d *decorated) CreateUserFeedback(ctx context.Context, opts CreateUserFeedbackOptions) (*UserFeedback, error) {
+	out, err := d.intercept(ctx, Call{Method: MethodCreateUserFeedback, Args: []interface{}{opts}}, func(ctx context.Context) (interface{}, error) {
+		return d.next.CreateUserFeedback(ctx, opts)
+	})
+	return interceptedResult[UserFeedback](MethodCreateUserFeedback, out, err)
+}
+
+// interceptedResult converts what an interceptor returned for a method to the method's result
+// type. A result of another type is an error rather than a silent nil.
+func interceptedResult[T any](method string, out interface{}, err error) (*T, error) {
+	if out == nil {
+		return nil, err
+	}
+	result, ok := out.(*T)
+	if !ok {
+		if err != nil {
+			return nil, err
+		}
+		return nil, &SDKError{
+			Message: fmt.Sprintf("interceptor returned %T from %s, expected %T", out, method, result),
+			Code:    ErrorCodeUnknownError,
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,142 @@
+package realitydefender_test
+
+import (
+	"context"
+	"errors"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API", func() {
+	var (
+		ctx  context.Context
+		mock *realitydefendertest.MockAPI
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mock = &realitydefendertest.MockAPI{
+			GetResultFunc: func(_ context.Context, requestID string, _ *realitydefender.GetResultOptions) (*realitydefender.DetectionResult, error) {
+				return &realitydefender.DetectionResult{RequestID: requestID, Status: realitydefender.StatusAuthentic}, nil
+			},
+		}
+	})
+
+	It("is implemented by Client", func() {
+		client, err := realitydefender.New(realitydefender.Config{APIKey: "test-api-key"})
+		Expect(err).NotTo(HaveOccurred())
+
+		var api realitydefender.API = client
+		var _ realitydefender.Uploader = api
+		var _ realitydefender.ResultGetter = api
+		var _ realitydefender.Detector = api
+		var _ realitydefender.FeedbackSubmitter = api
+	})
+
+	Describe("Decorate", func() {
+		It("runs calls through the interceptors, the first outermost", func() {
+			var order []string
+			trace := func(name string) realitydefender.Interceptor {
+				return func(ctx context.Context, call realitydefender.Call, invoke func(context.Context) (interface{}, error)) (interface{}, error) {
+					order = append(order, name+" "+call.Method)
+					return invoke(ctx)
+				}
+			}
+
+			api := realitydefender.Decorate(mock, trace("outer"), nil, trace("inner"))
+			result, err := api.GetResult(ctx, "req-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequestID).To(Equal("req-1"))
+			Expect(order).To(Equal([]string{"outer GetResult", "inner GetResult"}))
+		})
+
+		It("lets an interceptor answer from a cache", func() {
+			var (
+				mu    sync.Mutex
+				cache = map[string]interface{}{}
+			)
+			caching := func(ctx context.Context, call realitydefender.Call, invoke func(context.Context) (interface{}, error)) (interface{}, error) {
+				if call.Method != realitydefender.MethodGetResult {
+					return invoke(ctx)
+				}
+				key := call.Args[0].(string)
+				mu.Lock()
+				cached, ok := cache[key]
+				mu.Unlock()
+				if ok {
+					return cached, nil
+				}
+				out, err := invoke(ctx)
+				if err == nil {
+					mu.Lock()
+					cache[key] = out
+					mu.Unlock()
+				}
+				return out, err
+			}
+
+			api := realitydefender.Decorate(mock, caching)
+			for i := 0; i < 3; i++ {
+				result, err := api.GetResult(ctx, "req-1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+			}
+			Expect(mock.CallsTo(realitydefender.MethodGetResult)).To(HaveLen(1))
+		})
+
+		It("reports results of the wrong type", func() {
+			wrong := func(context.Context, realitydefender.Call, func(context.Context) (interface{}, error)) (interface{}, error) {
+				return &realitydefender.UploadResult{RequestID: "req-1"}, nil
+			}
+
+			result, err := realitydefender.Decorate(mock, wrong).GetResult(ctx, "req-1", nil)
+			Expect(result).To(BeNil())
+			Expect(err).To(MatchError(ContainSubstring("*realitydefender.UploadResult from GetResult")))
+			Expect(err).To(MatchError(realitydefender.ErrUnknown))
+		})
+
+		It("passes arguments and errors through every method", func() {
+			failure := errors.New("failed")
+			var calls []realitydefender.Call
+			api := realitydefender.Decorate(&realitydefendertest.MockAPI{
+				PollForResultsFunc: func(context.Context, string, *realitydefender.PollOptions) error {
+					return failure
+				},
+			}, func(ctx context.Context, call realitydefender.Call, invoke func(context.Context) (interface{}, error)) (interface{}, error) {
+				calls = append(calls, call)
+				return invoke(ctx)
+			})
+
+			Expect(api.PollForResults(ctx, "req-1", nil)).To(MatchError(failure))
+			_, err := api.Upload(ctx, realitydefender.UploadOptions{FilePath: "photo.jpg"})
+			Expect(err).To(HaveOccurred())
+			_, err = api.UploadSocialMedia(ctx, realitydefender.UploadSocialMediaOptions{})
+			Expect(err).To(HaveOccurred())
+			_, err = api.GetResults(ctx, nil, nil, nil, nil, nil, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = api.DetectFile(ctx, "photo.jpg")
+			Expect(err).To(HaveOccurred())
+			_, err = api.CreateUserFeedback(ctx, realitydefender.CreateUserFeedbackOptions{})
+			Expect(err).To(HaveOccurred())
+
+			var methods []string
+			for _, call := range calls {
+				methods = append(methods, call.Method)
+			}
+			Expect(methods).To(Equal([]string{
+				realitydefender.MethodPollForResults,
+				realitydefender.MethodUpload,
+				realitydefender.MethodUploadSocialMedia,
+				realitydefender.MethodGetResults,
+				realitydefender.MethodDetectFile,
+				realitydefender.MethodCreateUserFeedback,
+			}))
+			Expect(calls[0].Args[0]).To(Equal("req-1"))
+			Expect(calls[1].Args[0]).To(Equal(realitydefender.UploadOptions{FilePath: "photo.jpg"}))
+		})
+	})
+})
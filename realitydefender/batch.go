@@ -0,0 +1,283 @@
+package realitydefender
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default concurrency limits for DetectBatch
+const (
+	DefaultBatchUploadConcurrency = 4
+	DefaultBatchPollConcurrency   = 8
+)
+
+// BatchOptions configures DetectBatch
+type BatchOptions struct {
+	// UploadConcurrency is the maximum number of uploads in flight (default DefaultBatchUploadConcurrency)
+	UploadConcurrency int
+	// PollConcurrency is the maximum number of results being polled at once (default DefaultBatchPollConcurrency)
+	PollConcurrency int
+	// ResultOptions configures polling for each item's result
+	ResultOptions GetResultOptions
+	// Manifest records each item's upload and final result, and skips inputs it already holds.
+	// See Resume.
+	Manifest *Manifest
+}
+
+// BatchItemResult is the outcome of one batch input
+type BatchItemResult struct {
+	// Index is the position of the input in the slice passed to DetectBatch
+	Index int
+	// Input is the upload options the item was created from
+	Input UploadOptions
+	// RequestID is the request ID assigned by the upload, empty if the upload failed
+	RequestID string
+	// Hash is the hex SHA-256 of the input content, set when a manifest is used
+	Hash string
+	// Resumed is true when the upload was skipped because the manifest already recorded it
+	Resumed bool
+	// Cached is true when the result came from the client's result cache
+	Cached bool
+	// Result is the detection result, nil if Err is set
+	Result *DetectionResult
+	// Err is the upload or polling error for this item
+	Err error
+	// UploadDuration is the time spent uploading
+	UploadDuration time.Duration
+	// PollDuration is the time spent waiting for the result
+	PollDuration time.Duration
+}
+
+// BatchSummary aggregates the outcome of a batch
+type BatchSummary struct {
+	// Total is the number of inputs
+	Total int
+	// Succeeded is the number of items with a final detection result
+	Succeeded int
+	// Failed is the number of items with an error
+	Failed int
+	// Pending is the number of items still being analyzed when polling stopped after
+	// ResultOptions.MaxAttempts; their Result holds the last status seen
+	Pending int
+	// Resumed is the number of items whose upload was skipped thanks to the manifest
+	Resumed int
+	// StatusCounts counts succeeded items by detection status
+	StatusCounts map[Status]int
+	// Failures lists the failed items in input order
+	Failures []BatchItemResult
+	// Elapsed is the wall-clock time of the whole batch
+	Elapsed time.Duration
+	// UploadDuration is the total time spent uploading, summed over items
+	UploadDuration time.Duration
+	// PollDuration is the total time spent polling, summed over items
+	PollDuration time.Duration
+}
+
+// Batch is a running batch detection started by DetectBatch
+type Batch struct {
+	results chan BatchItemResult
+	done    chan struct{}
+	summary BatchSummary
+}
+
+// Results streams item results in completion order. The channel is closed once every input has a result.
+func (b *Batch) Results() <-chan BatchItemResult {
+	return b.results
+}
+
+// Wait blocks until the batch finishes and returns its summary.
+// Results not yet received from Results are discarded.
+func (b *Batch) Wait() *BatchSummary {
+	for range b.results {
+	}
+	<-b.done
+	return &b.summary
+}
+
+// DetectBatch uploads every input and waits for its detection result, with bounded upload and
+// poll concurrency. A failed item does not stop the batch; its error is reported in its result.
+// Cancelling ctx fails the items that have not finished.
+func (c *Client) DetectBatch(ctx context.Context, inputs []UploadOptions, options BatchOptions) *Batch {
+	uploadConcurrency := options.UploadConcurrency
+	if uploadConcurrency <= 0 {
+		uploadConcurrency = DefaultBatchUploadConcurrency
+	}
+	pollConcurrency := options.PollConcurrency
+	if pollConcurrency <= 0 {
+		pollConcurrency = DefaultBatchPollConcurrency
+	}
+
+	batch := &Batch{
+		results: make(chan BatchItemResult),
+		done:    make(chan struct{}),
+		summary: BatchSummary{
+			Total:        len(inputs),
+			StatusCounts: map[Status]int{},
+		},
+	}
+
+	pending := make(chan int)
+	uploaded := make(chan BatchItemResult)
+	finished := make(chan BatchItemResult)
+
+	go func() {
+		defer close(pending)
+		for i := range inputs {
+			pending <- i
+		}
+	}()
+
+	var uploaders sync.WaitGroup
+	for i := 0; i < uploadConcurrency; i++ {
+		uploaders.Add(1)
+		go func() {
+			defer uploaders.Done()
+			for index := range pending {
+				item := BatchItemResult{Index: index, Input: inputs[index]}
+				if err := ctx.Err(); err != nil {
+					item.Err = err
+					finished <- item
+					continue
+				}
+
+				if err := c.uploadItem(ctx, &item, options.Manifest); err != nil {
+					item.Err = err
+					finished <- item
+					continue
+				}
+				// Items with a result from the manifest or the cache need no polling
+				if item.Result != nil {
+					finished <- item
+					continue
+				}
+				uploaded <- item
+			}
+		}()
+	}
+	go func() {
+		uploaders.Wait()
+		close(uploaded)
+	}()
+
+	var pollers sync.WaitGroup
+	for i := 0; i < pollConcurrency; i++ {
+		pollers.Add(1)
+		go func() {
+			defer pollers.Done()
+			for item := range uploaded {
+				start := time.Now()
+				resultOptions := options.ResultOptions
+				if resultOptions.MediaType == MediaTypeUnknown {
+					resultOptions.MediaType = MediaTypeOf(inputSource(item.Input))
+				}
+				item.Result, item.Err = getDetectionResult(ctx, c.httpClient, item.RequestID, resultOptions, c.emitPollAttempt)
+				item.PollDuration = time.Since(start)
+				c.handleResult(ctx, item.Result)
+
+				if options.Manifest != nil && item.Err == nil && item.Result.IsFinal() {
+					c.recordManifest(ctx, options.Manifest, ManifestEntry{Hash: item.Hash, Result: item.Result})
+				}
+				finished <- item
+			}
+		}()
+	}
+	go func() {
+		uploaders.Wait()
+		pollers.Wait()
+		close(finished)
+	}()
+
+	start := time.Now()
+	go func() {
+		defer close(batch.done)
+		defer close(batch.results)
+		for item := range finished {
+			batch.summary.add(item)
+			batch.results <- item
+		}
+		sort.Slice(batch.summary.Failures, func(i, j int) bool {
+			return batch.summary.Failures[i].Index < batch.summary.Failures[j].Index
+		})
+		batch.summary.Elapsed = time.Since(start)
+	}()
+
+	return batch
+}
+
+// uploadItem uploads a batch item unless the manifest already holds its content, setting its
+// request ID and any result known already. Items with the same content are uploaded one at a
+// time, so only the first is uploaded and the rest find its manifest entry.
+func (c *Client) uploadItem(ctx context.Context, item *BatchItemResult, manifest *Manifest) error {
+	if manifest != nil {
+		hash, err := contentHash(item.Input)
+		if err != nil {
+			return err
+		}
+		item.Hash = hash
+
+		unlock, err := manifest.uploads.lock(ctx, hash)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		if entry, ok := manifest.Entry(hash); ok && entry.RequestID != "" {
+			item.RequestID = entry.RequestID
+			item.Result = entry.Result
+			item.Resumed = true
+			return nil
+		}
+	}
+
+	start := time.Now()
+	upload, cached, err := c.uploadCached(ctx, item.Input)
+	item.UploadDuration = time.Since(start)
+	if err != nil {
+		return err
+	}
+	item.RequestID = upload.RequestID
+	item.Result = cached
+	item.Cached = cached != nil
+
+	if manifest != nil {
+		uploadedAt := time.Now()
+		c.recordManifest(ctx, manifest, ManifestEntry{
+			Hash:       item.Hash,
+			Source:     inputSource(item.Input),
+			RequestID:  item.RequestID,
+			UploadedAt: &uploadedAt,
+			Result:     item.Result,
+		})
+	}
+	return nil
+}
+
+// add records one item in the summary
+func (s *BatchSummary) add(item BatchItemResult) {
+	s.UploadDuration += item.UploadDuration
+	s.PollDuration += item.PollDuration
+	if item.Resumed {
+		s.Resumed++
+	}
+	if item.Err != nil {
+		s.Failed++
+		s.Failures = append(s.Failures, item)
+		return
+	}
+	if !item.Result.IsFinal() {
+		s.Pending++
+		return
+	}
+	s.Succeeded++
+	s.StatusCounts[item.Result.Status]++
+}
+
+// recordManifest writes a manifest update. A failed write only costs the ability to resume
+// this item, so it is logged rather than failing the item.
+func (c *Client) recordManifest(ctx context.Context, manifest *Manifest, entry ManifestEntry) {
+	if err := manifest.record(entry); err != nil {
+		c.httpClient.logger.WarnContext(ctx, "failed to record batch progress", "hash", entry.Hash, "error", err)
+	}
+}
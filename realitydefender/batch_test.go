@@ -0,0 +1,129 @@
+package realitydefender_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DetectBatch", func() {
+	var (
+		server      *realitydefendertest.Server
+		client      *realitydefender.Client
+		inFlight    int32
+		maxInFlight int32
+	)
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&inFlight, 0)
+		atomic.StoreInt32(&maxInFlight, 0)
+		server = newFakeServer()
+		server.SetDefaultOutcome(realitydefendertest.Outcome{Status: "AUTHENTIC"})
+		server.SetOutcome("fake-1.txt", realitydefendertest.Outcome{Status: "FAKE"})
+		server.SetOutcome("fake-2.txt", realitydefendertest.Outcome{Status: "FAKE"})
+
+		// Slows uploads down and tracks how many run at once
+		tracker := func(next realitydefender.Doer) realitydefender.Doer {
+			return realitydefender.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				info, _ := realitydefender.RequestInfoFromContext(req.Context())
+				if info.Endpoint != realitydefender.EndpointUpload {
+					return next.Do(req)
+				}
+
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					seen := atomic.LoadInt32(&maxInFlight)
+					if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return next.Do(req)
+			})
+		}
+
+		var err error
+		client, err = server.NewClient(realitydefender.Config{Middleware: []realitydefender.Middleware{tracker}})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	input := func(name string) realitydefender.UploadOptions {
+		return realitydefender.UploadOptions{FileName: name, Data: []byte("content")}
+	}
+
+	It("streams every item and summarizes statuses", func() {
+		inputs := []realitydefender.UploadOptions{
+			input("real-1.txt"), input("fake-1.txt"), input("real-2.txt"), input("fake-2.txt"), input("real-3.txt"),
+		}
+
+		batch := client.DetectBatch(context.Background(), inputs, realitydefender.BatchOptions{UploadConcurrency: 2})
+
+		seen := map[int]realitydefender.Status{}
+		for item := range batch.Results() {
+			Expect(item.Err).NotTo(HaveOccurred())
+			Expect(item.RequestID).To(Equal(item.Result.RequestID))
+			Expect(item.UploadDuration).To(BeNumerically(">", 0))
+			seen[item.Index] = item.Result.Status
+		}
+		Expect(seen).To(HaveLen(5))
+		Expect(seen[1]).To(Equal(realitydefender.StatusManipulated))
+		Expect(seen[3]).To(Equal(realitydefender.StatusManipulated))
+
+		summary := batch.Wait()
+		Expect(summary.Total).To(Equal(5))
+		Expect(summary.Succeeded).To(Equal(5))
+		Expect(summary.Failed).To(BeZero())
+		Expect(summary.StatusCounts).To(Equal(map[realitydefender.Status]int{realitydefender.StatusAuthentic: 3, realitydefender.StatusManipulated: 2}))
+		Expect(summary.Elapsed).To(BeNumerically(">=", summary.UploadDuration/2))
+		Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", 2))
+	})
+
+	It("counts items still analyzing when polling stops as pending", func() {
+		server.SetOutcome("slow.txt", realitydefendertest.Outcome{AnalyzingPolls: 5})
+		inputs := []realitydefender.UploadOptions{input("real-1.txt"), input("slow.txt")}
+
+		summary := client.DetectBatch(context.Background(), inputs, realitydefender.BatchOptions{
+			ResultOptions: realitydefender.GetResultOptions{MaxAttempts: 1},
+		}).Wait()
+
+		Expect(summary.Succeeded).To(Equal(1))
+		Expect(summary.Pending).To(Equal(1))
+		Expect(summary.Failed).To(BeZero())
+		Expect(summary.StatusCounts).To(Equal(map[realitydefender.Status]int{realitydefender.StatusAuthentic: 1}))
+	})
+
+	It("reports per-item errors without aborting the batch", func() {
+		inputs := []realitydefender.UploadOptions{
+			input("real-1.txt"),
+			{FilePath: "/does/not/exist.jpg"},
+			input("real-2.txt"),
+			{FileName: "unsupported.exe", Data: []byte("content")},
+		}
+
+		summary := client.DetectBatch(context.Background(), inputs, realitydefender.BatchOptions{}).Wait()
+
+		Expect(summary.Succeeded).To(Equal(2))
+		Expect(summary.Failed).To(Equal(2))
+		Expect(summary.Failures).To(HaveLen(2))
+		Expect(summary.Failures[0].Index).To(Equal(1))
+		Expect(summary.Failures[1].Index).To(Equal(3))
+		Expect(summary.Failures[0].Err).To(MatchError(realitydefender.ErrInvalidFile))
+	})
+
+	It("fails the remaining items when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		summary := client.DetectBatch(ctx, []realitydefender.UploadOptions{input("a.txt"), input("b.txt")}, realitydefender.BatchOptions{}).Wait()
+
+		Expect(summary.Failed).To(Equal(2))
+		Expect(summary.Failures[0].Err).To(MatchError(context.Canceled))
+	})
+})
@@ -0,0 +1,371 @@
+package realitydefender
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultMemoryCacheSize is the capacity of NewMemoryCache when none is given
+const DefaultMemoryCacheSize = 1000
+
+// CacheEntry is what a ResultCache stores for a content hash
+type CacheEntry struct {
+	// RequestID is the request ID of the upload of this content
+	RequestID string `json:"requestId"`
+	// Result is the final detection result, nil until it is known
+	Result *DetectionResult `json:"result,omitempty"`
+	// StoredAt is when the entry was last written; entries older than Config.CacheTTL are ignored
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// ResultCache stores detection results by a key made of the hex SHA-256 of the media content
+// and a hash of the client's API key and base URL, so identical media is not uploaded twice
+// and clients of different accounts can share a cache. Implementations must be safe for
+// concurrent use.
+type ResultCache interface {
+	// Get returns the entry for key, reporting false when there is none
+	Get(ctx context.Context, key string) (CacheEntry, bool, error)
+	// Set stores the entry for key
+	Set(ctx context.Context, key string, entry CacheEntry) error
+}
+
+// memoryCache is an in-memory least-recently-used ResultCache
+type memoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+// memoryCacheItem is an element of the LRU list
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewMemoryCache creates an in-memory ResultCache holding up to capacity entries, evicting the
+// least recently used one when full. A capacity below 1 uses DefaultMemoryCacheSize.
+func NewMemoryCache(capacity int) ResultCache {
+	if capacity < 1 {
+		capacity = DefaultMemoryCacheSize
+	}
+	return &memoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+// Get implements ResultCache
+func (c *memoryCache) Get(_ context.Context, key string) (CacheEntry, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false, nil
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true, nil
+}
+
+// Set implements ResultCache
+func (c *memoryCache) Set(_ context.Context, key string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*memoryCacheItem).key)
+	}
+	return nil
+}
+
+// fileCache is a ResultCache storing one JSON file per entry in a directory
+type fileCache struct {
+	dir string
+}
+
+// NewFileCache creates a ResultCache that persists entries as JSON files in dir, creating it if needed
+func NewFileCache(dir string) (ResultCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to create cache directory: %v", err),
+			Code:    ErrorCodeInvalidRequest,
+			Err:     err,
+		}
+	}
+	return &fileCache{dir: dir}, nil
+}
+
+// path returns the file holding the entry for key
+func (c *fileCache) path(key string) string {
+	return filepath.Join(c.dir, filepath.Base(key)+".json")
+}
+
+// Get implements ResultCache
+func (c *fileCache) Get(_ context.Context, key string) (CacheEntry, bool, error) {
+	var entry CacheEntry
+
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return entry, false, nil
+	}
+	if err != nil {
+		return entry, false, err
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, false, err
+	}
+	return entry, true, nil
+}
+
+// Set implements ResultCache. The entry is written to a temporary file and renamed into
+// place, so readers never see a partial entry.
+func (c *fileCache) Set(_ context.Context, key string, entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// cacheScope identifies the account and API a client uses, so that clients sharing a cache
+// never see each other's request IDs. The API key is hashed so it is not stored in keys.
+func cacheScope(baseURL, apiKey string) string {
+	sum := sha256.Sum256([]byte(baseURL + "\n" + apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheKey hashes the upload's content into its cache key. It returns an empty key when the
+// client has no cache or the content cannot be cached, in which case the upload proceeds uncached.
+func (c *Client) cacheKey(options UploadOptions) string {
+	if c.cache == nil {
+		return ""
+	}
+
+	hash, err := contentHash(options)
+	if err != nil {
+		return ""
+	}
+	return c.cacheScope + "-" + hash
+}
+
+// cacheLookup looks a cache key up in the client's cache, ignoring expired entries
+func (c *Client) cacheLookup(ctx context.Context, key string) (CacheEntry, bool) {
+	entry, hit, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.httpClient.logger.WarnContext(ctx, "result cache lookup failed", "key", key, "error", err)
+		return entry, false
+	}
+	if hit && (entry.RequestID == "" || (c.cacheTTL > 0 && time.Since(entry.StoredAt) > c.cacheTTL)) {
+		return entry, false
+	}
+
+	if hit {
+		c.httpClient.logger.DebugContext(ctx, "result cache hit",
+			"key", key,
+			"request_id", entry.RequestID,
+			"has_result", entry.Result != nil,
+		)
+	}
+	return entry, hit
+}
+
+// cacheStore writes an entry to the client's cache. Failures are logged, since the cache
+// is only an optimization.
+func (c *Client) cacheStore(ctx context.Context, key string, entry CacheEntry) {
+	if c.cache == nil || key == "" {
+		return
+	}
+
+	entry.StoredAt = time.Now()
+	if err := c.cache.Set(ctx, key, entry); err != nil {
+		c.httpClient.logger.WarnContext(ctx, "result cache store failed", "key", key, "error", err)
+	}
+}
+
+// cacheResult stores a final result for a request ID uploaded through the cache
+func (c *Client) cacheResult(ctx context.Context, result *DetectionResult) {
+	if result == nil || !result.IsFinal() {
+		return
+	}
+	key, ok := c.cachedUploads.take(result.RequestID)
+	if !ok {
+		return
+	}
+	c.cacheStore(ctx, key, CacheEntry{RequestID: result.RequestID, Result: result})
+}
+
+// uploadCached uploads the media unless the cache holds its content. On a hit it returns the
+// cached request ID and, when known, the cached result. Concurrent uploads of the same content
+// wait for each other, so the first uploads it and the rest find its request ID in the cache.
+func (c *Client) uploadCached(ctx context.Context, options UploadOptions) (*UploadResult, *DetectionResult, error) {
+	key := c.cacheKey(options)
+	if key != "" && !options.BypassCache {
+		unlock, err := c.uploadLocks.lock(ctx, key)
+		if err != nil {
+			return nil, nil, &SDKError{
+				Message: fmt.Sprintf("upload failed: %v", err),
+				Code:    ErrorCodeTimeout,
+				Err:     err,
+			}
+		}
+		defer unlock()
+
+		if entry, hit := c.cacheLookup(ctx, key); hit {
+			if entry.Result == nil {
+				c.cachedUploads.store(entry.RequestID, key)
+			}
+			return &UploadResult{RequestID: entry.RequestID}, entry.Result, nil
+		}
+	}
+
+	result, err := uploadFile(ctx, c.httpClient, c.withProgressEvents(options))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if key != "" {
+		c.cacheStore(ctx, key, CacheEntry{RequestID: result.RequestID})
+		c.cachedUploads.store(result.RequestID, key)
+	}
+	return result, nil, nil
+}
+
+// keyLocks holds a lock per key, created on first use and dropped once no one holds or
+// waits for it. The zero value is ready to use.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock is the lock of one key
+type keyLock struct {
+	held  chan struct{}
+	users int
+}
+
+// lock waits until no one else holds key, or until ctx is done. It returns the function that
+// releases the lock.
+func (l *keyLocks) lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*keyLock{}
+	}
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &keyLock{held: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.users++
+	l.mu.Unlock()
+
+	release := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if lock.users--; lock.users == 0 {
+			delete(l.locks, key)
+		}
+	}
+
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			release()
+		}, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// maxPendingUploads is how many uploads awaiting a final result pendingUploads remembers
+const maxPendingUploads = DefaultMemoryCacheSize
+
+// pendingUploads maps request IDs uploaded through the cache to their cache key until their
+// final result is cached. Results that are never fetched would leave their entries behind,
+// so it keeps only the newest maxPendingUploads. The zero value is ready to use.
+type pendingUploads struct {
+	mu    sync.Mutex
+	order *list.List
+	keys  map[string]*list.Element
+}
+
+// pendingUpload is an element of the pendingUploads list
+type pendingUpload struct {
+	requestID string
+	key       string
+}
+
+// store remembers the cache key of a request ID, dropping the oldest entry when full
+func (p *pendingUploads) store(requestID, key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys == nil {
+		p.order = list.New()
+		p.keys = map[string]*list.Element{}
+	}
+	if element, ok := p.keys[requestID]; ok {
+		element.Value.(*pendingUpload).key = key
+		p.order.MoveToFront(element)
+		return
+	}
+
+	p.keys[requestID] = p.order.PushFront(&pendingUpload{requestID: requestID, key: key})
+	if p.order.Len() > maxPendingUploads {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.keys, oldest.Value.(*pendingUpload).requestID)
+	}
+}
+
+// take returns and forgets the cache key of a request ID
+func (p *pendingUploads) take(requestID string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	element, ok := p.keys[requestID]
+	if !ok {
+		return "", false
+	}
+	p.order.Remove(element)
+	delete(p.keys, requestID)
+	return element.Value.(*pendingUpload).key, true
+}
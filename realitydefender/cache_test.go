@@ -0,0 +1,207 @@
+package realitydefender_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Result cache", func() {
+	var server *realitydefendertest.Server
+
+	BeforeEach(func() {
+		server = newFakeServer()
+	})
+
+	uploads := func() int {
+		return len(server.RequestsTo(realitydefendertest.EndpointSignedURL))
+	}
+
+	polls := func() int {
+		return len(server.RequestsTo(realitydefendertest.EndpointMediaResult))
+	}
+
+	newClient := func(cache realitydefender.ResultCache, ttl time.Duration) *realitydefender.Client {
+		client, err := server.NewClient(realitydefender.Config{Cache: cache, CacheTTL: ttl})
+		Expect(err).NotTo(HaveOccurred())
+		return client
+	}
+
+	hashOf := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	It("reuses the request ID of identical content", func() {
+		client := newClient(realitydefender.NewMemoryCache(10), 0)
+
+		first, err := client.Upload(context.Background(), realitydefender.UploadOptions{FileName: "a.txt", Data: []byte("viral")})
+		Expect(err).NotTo(HaveOccurred())
+		second, err := client.Upload(context.Background(), realitydefender.UploadOptions{FileName: "b.txt", Data: []byte("viral")})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second.RequestID).To(Equal(first.RequestID))
+		Expect(uploads()).To(Equal(1))
+	})
+
+	It("returns cached results from DetectFile without API calls", func() {
+		dir := GinkgoT().TempDir()
+		cache, err := realitydefender.NewFileCache(filepath.Join(dir, "cache"))
+		Expect(err).NotTo(HaveOccurred())
+
+		path := filepath.Join(dir, "clip.txt")
+		Expect(os.WriteFile(path, []byte("viral"), 0o600)).To(Succeed())
+
+		first, err := newClient(cache, 0).DetectFile(context.Background(), path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.Status).To(Equal(realitydefender.StatusManipulated))
+
+		// A new client sharing the file cache sees the result
+		second, err := newClient(cache, 0).DetectFile(context.Background(), path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(Equal(first))
+		Expect(uploads()).To(Equal(1))
+		Expect(polls()).To(Equal(1))
+	})
+
+	It("uploads again when bypassed or expired", func() {
+		ctx := context.Background()
+		cache := &recordingCache{ResultCache: realitydefender.NewMemoryCache(10)}
+		client := newClient(cache, time.Hour)
+
+		result, err := client.Upload(ctx, realitydefender.UploadOptions{FileName: "a.txt", Data: []byte("viral")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequestID).To(Equal("request-1"))
+
+		// Age the entry past the TTL
+		key := cache.lastKey()
+		Expect(key).To(HaveSuffix(hashOf("viral")))
+		entry, _, _ := cache.Get(ctx, key)
+		entry.StoredAt = time.Now().Add(-2 * time.Hour)
+		Expect(cache.Set(ctx, key, entry)).To(Succeed())
+
+		result, err = client.Upload(ctx, realitydefender.UploadOptions{FileName: "a.txt", Data: []byte("viral")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequestID).To(Equal("request-2"))
+
+		result, err = client.Upload(ctx, realitydefender.UploadOptions{FileName: "a.txt", Data: []byte("viral"), BypassCache: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequestID).To(Equal("request-3"))
+		Expect(uploads()).To(Equal(3))
+	})
+
+	It("keeps the entries of different accounts apart", func() {
+		cache := realitydefender.NewMemoryCache(10)
+		first, err := newClient(cache, 0).Upload(context.Background(), realitydefender.UploadOptions{FileName: "a.txt", Data: []byte("viral")})
+		Expect(err).NotTo(HaveOccurred())
+
+		other, err := server.NewClient(realitydefender.Config{APIKey: "other-api-key", Cache: cache})
+		Expect(err).NotTo(HaveOccurred())
+		second, err := other.Upload(context.Background(), realitydefender.UploadOptions{FileName: "a.txt", Data: []byte("viral")})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second.RequestID).NotTo(Equal(first.RequestID))
+		Expect(uploads()).To(Equal(2))
+	})
+
+	It("serves batch items from the cache", func() {
+		client := newClient(realitydefender.NewMemoryCache(10), 0)
+		inputs := []realitydefender.UploadOptions{{FileName: "a.txt", Data: []byte("viral")}}
+
+		client.DetectBatch(context.Background(), inputs, realitydefender.BatchOptions{}).Wait()
+		batch := client.DetectBatch(context.Background(), inputs, realitydefender.BatchOptions{})
+		item := <-batch.Results()
+		batch.Wait()
+
+		Expect(item.Cached).To(BeTrue())
+		Expect(item.Result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(polls()).To(Equal(1))
+	})
+
+	It("does not cache results whose models are still analyzing", func() {
+		server.SetDefaultOutcome(realitydefendertest.Outcome{
+			Status: "AUTHENTIC",
+			Models: []realitydefendertest.Model{{Name: "rd-img", Status: "AUTHENTIC", AnalyzingPolls: 1}},
+		})
+		client := newClient(realitydefender.NewMemoryCache(10), 0)
+		inputs := []realitydefender.UploadOptions{{FileName: "a.txt", Data: []byte("viral")}}
+		options := realitydefender.BatchOptions{ResultOptions: realitydefender.GetResultOptions{MaxAttempts: 1}}
+
+		batch := client.DetectBatch(context.Background(), inputs, options)
+		first := <-batch.Results()
+		batch.Wait()
+		Expect(first.Result.IsFinal()).To(BeFalse())
+
+		batch = client.DetectBatch(context.Background(), inputs, options)
+		second := <-batch.Results()
+		batch.Wait()
+		Expect(second.Result.IsFinal()).To(BeTrue())
+		Expect(polls()).To(Equal(2))
+	})
+
+	It("uploads identical batch items once", func() {
+		client := newClient(realitydefender.NewMemoryCache(10), 0)
+		inputs := []realitydefender.UploadOptions{
+			{FileName: "a.txt", Data: []byte("viral")},
+			{FileName: "b.txt", Data: []byte("viral")},
+			{FileName: "c.txt", Data: []byte("viral")},
+		}
+
+		batch := client.DetectBatch(context.Background(), inputs, realitydefender.BatchOptions{UploadConcurrency: 3})
+		var requestIDs []string
+		for item := range batch.Results() {
+			Expect(item.Err).NotTo(HaveOccurred())
+			requestIDs = append(requestIDs, item.RequestID)
+		}
+		batch.Wait()
+
+		Expect(requestIDs).To(Equal([]string{"request-1", "request-1", "request-1"}))
+		Expect(uploads()).To(Equal(1))
+	})
+
+	Describe("NewMemoryCache", func() {
+		It("evicts the least recently used entry", func() {
+			ctx := context.Background()
+			cache := realitydefender.NewMemoryCache(2)
+			Expect(cache.Set(ctx, "a", realitydefender.CacheEntry{RequestID: "1"})).To(Succeed())
+			Expect(cache.Set(ctx, "b", realitydefender.CacheEntry{RequestID: "2"})).To(Succeed())
+			_, _, _ = cache.Get(ctx, "a")
+			Expect(cache.Set(ctx, "c", realitydefender.CacheEntry{RequestID: "3"})).To(Succeed())
+
+			_, ok, _ := cache.Get(ctx, "b")
+			Expect(ok).To(BeFalse())
+			entry, ok, _ := cache.Get(ctx, "a")
+			Expect(ok).To(BeTrue())
+			Expect(entry.RequestID).To(Equal("1"))
+		})
+	})
+})
+
+// recordingCache remembers the keys stored in a ResultCache
+type recordingCache struct {
+	realitydefender.ResultCache
+	mu   sync.Mutex
+	keys []string
+}
+
+func (c *recordingCache) Set(ctx context.Context, key string, entry realitydefender.CacheEntry) error {
+	c.mu.Lock()
+	c.keys = append(c.keys, key)
+	c.mu.Unlock()
+	return c.ResultCache.Set(ctx, key, entry)
+}
+
+func (c *recordingCache) lastKey() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.keys[len(c.keys)-1]
+}
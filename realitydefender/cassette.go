@@ -0,0 +1,400 @@
+package realitydefender
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrCassetteMiss is returned, wrapped, when a replaying Cassette has no recorded
+// interaction for a request
+var ErrCassetteMiss = errors.New("cassette has no recorded interaction for the request")
+
+// CassetteMode selects whether a Cassette replays or records
+type CassetteMode int
+
+// Cassette modes
+const (
+	// CassetteReplay answers requests from the cassette file without network access
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord sends requests and writes them to the cassette file, replacing its contents
+	CassetteRecord
+)
+
+// Cassette records the requests a client sends and the responses it gets to a JSON file,
+// and replays them later so tests run without network access. Set Config.Cassette to use it.
+//
+// API keys and presigned URL signatures are redacted from the file, and uploaded media is
+// stored as a SHA-256 hash. A replayed request matches the first unused interaction with the
+// same method, URL and body, so repeated polls replay in the order they were recorded.
+type Cassette struct {
+	path string
+	mode CassetteMode
+
+	mu           sync.Mutex
+	interactions []cassetteInteraction
+	used         []bool
+}
+
+// cassetteFile is the format of a cassette file
+type cassetteFile struct {
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+// cassetteInteraction is a request and the response it got
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+// cassetteRequest is a recorded request. Uploads store BodySHA256 instead of Body.
+type cassetteRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodySHA256 string      `json:"bodySha256,omitempty"`
+}
+
+// cassetteResponse is a recorded response
+type cassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// NewCassette opens a cassette file. In CassetteReplay mode the file must exist; in
+// CassetteRecord mode it is created, or emptied if it exists.
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	cassette := &Cassette{path: path, mode: mode}
+
+	switch mode {
+	case CassetteReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &SDKError{Message: fmt.Sprintf("failed to read cassette: %v", err), Code: ErrorCodeInvalidFile, Err: err}
+		}
+		var file cassetteFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, &SDKError{Message: fmt.Sprintf("invalid cassette %s: %v", path, err), Code: ErrorCodeInvalidFile, Err: err}
+		}
+		cassette.interactions = file.Interactions
+		cassette.used = make([]bool, len(file.Interactions))
+	case CassetteRecord:
+		if err := cassette.save(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, &SDKError{Message: fmt.Sprintf("unknown cassette mode %d", mode), Code: ErrorCodeInvalidRequest}
+	}
+
+	return cassette, nil
+}
+
+// Unused returns the number of recorded interactions not replayed yet
+func (c *Cassette) Unused() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unused := 0
+	for _, used := range c.used {
+		if !used {
+			unused++
+		}
+	}
+	return unused
+}
+
+// transport returns a RoundTripper that records requests sent through next, or replays them
+func (c *Cassette) transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+// cassetteTransport sends requests through a Cassette
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.cassette.mode == CassetteReplay {
+		return t.cassette.replay(req)
+	}
+	return t.cassette.record(req, t.next)
+}
+
+// replay answers a request with the first unused matching interaction
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	recorded, err := readCassetteRequest(req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		c.used[i] = true
+
+		body := interaction.Response.Body
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s in %s", ErrCassetteMiss, recorded.Method, recorded.URL, c.path)
+}
+
+// record sends a request through next and saves the interaction
+func (c *Cassette) record(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	// Upload bodies are hashed as they are sent rather than held in memory
+	var upload *hashingBody
+	if req.Method == http.MethodPut && req.Body != nil && req.Body != http.NoBody {
+		upload = &hashingBody{body: req.Body, hasher: sha256.New(), done: make(chan struct{})}
+		clone := req.Clone(req.Context())
+		clone.Body = upload
+		req = clone
+	}
+	recorded, err := readCassetteRequest(req, upload != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if upload != nil {
+		// The transport may still be sending the body when the response arrives
+		sum, err := upload.wait(req.Context())
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		recorded.BodySHA256 = sum
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, cassetteInteraction{
+		Request: recorded,
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       redactBody(body),
+		},
+	})
+	c.used = append(c.used, true)
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the interactions to the cassette file; c.mu must be held once in use
+func (c *Cassette) save() error {
+	interactions := c.interactions
+	if interactions == nil {
+		interactions = []cassetteInteraction{}
+	}
+	data, err := json.MarshalIndent(cassetteFile{Interactions: interactions}, "", "  ")
+	if err != nil {
+		return &SDKError{Message: fmt.Sprintf("failed to encode cassette: %v", err), Code: ErrorCodeUnknownError, Err: err}
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o600); err != nil {
+		return &SDKError{Message: fmt.Sprintf("failed to write cassette: %v", err), Code: ErrorCodeUnknownError, Err: err}
+	}
+	return nil
+}
+
+// readCassetteRequest describes a request as it is stored. JSON bodies are read and restored;
+// upload bodies are hashed here, unless hashed is set because the caller hashes them as they
+// are sent.
+func readCassetteRequest(req *http.Request, hashed bool) (cassetteRequest, error) {
+	recorded := cassetteRequest{
+		Method: req.Method,
+		URL:    redactURL(req.URL.String()),
+		Header: redactHeader(req.Header),
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	if req.Method == http.MethodPut {
+		if !hashed {
+			sum := sha256.New()
+			if _, err := io.Copy(sum, req.Body); err != nil {
+				return recorded, err
+			}
+			recorded.BodySHA256 = hex.EncodeToString(sum.Sum(nil))
+		}
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = string(body)
+	return recorded, nil
+}
+
+// matches reports whether a replayed request is the recorded one
+func (r cassetteRequest) matches(other cassetteRequest) bool {
+	return r.Method == other.Method && r.URL == other.URL && r.Body == other.Body && r.BodySHA256 == other.BodySHA256
+}
+
+// redactHeader copies headers with sensitive values replaced and trace context removed,
+// which differs on every run
+func redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	redactedHeader := make(http.Header, len(header))
+	for name, values := range header {
+		switch canonical := http.CanonicalHeaderKey(name); {
+		case sensitiveHeaders[canonical]:
+			redactedHeader[name] = []string{redacted}
+		case canonical == "Traceparent" || canonical == "Tracestate" || canonical == "Baggage":
+			// Dropped
+		default:
+			redactedHeader[name] = append([]string(nil), values...)
+		}
+	}
+	return redactedHeader
+}
+
+// redactBody redacts presigned URLs in a JSON response body. Other bodies are returned unchanged.
+func redactBody(body []byte) string {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	changed := false
+	value = redactValue(value, &changed)
+	if !changed {
+		return string(body)
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// redactValue redacts presigned URLs in a decoded JSON value
+func redactValue(value interface{}, changed *bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = redactValue(item, changed)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, changed)
+		}
+	case string:
+		if parsed, err := url.Parse(v); err == nil && parsed.Scheme != "" {
+			if redactedURL := redactURL(v); redactedURL != v {
+				*changed = true
+				return redactedURL
+			}
+		}
+	}
+	return value
+}
+
+// hashingBody hashes an upload body as the transport reads it. The transport may close it
+// after RoundTrip returns, so the hash is only complete once Close has run; Close also hashes
+// whatever the transport did not read, so the hash always covers the whole body.
+type hashingBody struct {
+	body   io.ReadCloser
+	done   chan struct{}
+	mu     sync.Mutex
+	hasher hash.Hash
+	closed bool
+	sum    string
+	err    error
+}
+
+// Read implements io.Reader
+func (b *hashingBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, http.ErrBodyReadAfterClose
+	}
+	n, err := b.body.Read(p)
+	b.hasher.Write(p[:n])
+	return n, err
+}
+
+// Close implements io.Closer
+func (b *hashingBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	if _, err := io.Copy(b.hasher, b.body); err != nil {
+		b.err = err
+	}
+	b.sum = hex.EncodeToString(b.hasher.Sum(nil))
+	close(b.done)
+	return b.body.Close()
+}
+
+// wait returns the hash of the body once the transport has closed it
+func (b *hashingBody) wait(ctx context.Context) (string, error) {
+	select {
+	case <-b.done:
+		return b.sum, b.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
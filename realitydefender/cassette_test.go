@@ -0,0 +1,154 @@
+package realitydefender_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cassette", func() {
+	var (
+		server       *realitydefendertest.Server
+		dir          string
+		cassettePath string
+		mediaPath    string
+		media        = []byte("\x89PNG\r\n\x1a\nsecret media")
+	)
+
+	BeforeEach(func() {
+		score := 91.0
+		server = newFakeServer()
+		server.SetDefaultOutcome(realitydefendertest.Outcome{Status: "FAKE", Score: &score})
+
+		dir = GinkgoT().TempDir()
+		cassettePath = filepath.Join(dir, "cassette.json")
+		mediaPath = filepath.Join(dir, "photo.png")
+		Expect(os.WriteFile(mediaPath, media, 0o600)).To(Succeed())
+	})
+
+	newClient := func(mode realitydefender.CassetteMode) (*realitydefender.Client, *realitydefender.Cassette) {
+		cassette, err := realitydefender.NewCassette(cassettePath, mode)
+		Expect(err).NotTo(HaveOccurred())
+		client, err := realitydefender.New(realitydefender.Config{
+			APIKey:   "secret-api-key",
+			BaseURL:  server.URL,
+			Cassette: cassette,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return client, cassette
+	}
+
+	record := func() {
+		client, _ := newClient(realitydefender.CassetteRecord)
+		result, err := client.DetectFile(context.Background(), mediaPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(server.RequestsTo(realitydefendertest.EndpointUpload)[0].Body).To(Equal(media))
+	}
+
+	It("records interactions with secrets and media left out", func() {
+		record()
+
+		data, err := os.ReadFile(cassettePath)
+		Expect(err).NotTo(HaveOccurred())
+		contents := string(data)
+		Expect(contents).To(ContainSubstring(`"url": "` + server.URL + `/api/files/aws-presigned"`))
+		Expect(contents).To(ContainSubstring(`/upload/request-1?REDACTED`))
+		Expect(contents).NotTo(ContainSubstring("secret-api-key"))
+		Expect(contents).NotTo(ContainSubstring("fake-signature"))
+		Expect(contents).NotTo(ContainSubstring("secret media"))
+
+		sum := sha256.Sum256(media)
+		Expect(contents).To(ContainSubstring(`"bodySha256": "` + hex.EncodeToString(sum[:]) + `"`))
+	})
+
+	It("hashes the whole upload when the transport sends it after responding", func() {
+		cassette, err := realitydefender.NewCassette(cassettePath, realitydefender.CassetteRecord)
+		Expect(err).NotTo(HaveOccurred())
+		client, err := realitydefender.New(realitydefender.Config{
+			APIKey:    "secret-api-key",
+			BaseURL:   server.URL,
+			Cassette:  cassette,
+			Transport: lateUploadTransport{},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Upload(context.Background(), realitydefender.UploadOptions{FilePath: mediaPath})
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := os.ReadFile(cassettePath)
+		Expect(err).NotTo(HaveOccurred())
+		sum := sha256.Sum256(media)
+		Expect(string(data)).To(ContainSubstring(`"bodySha256": "` + hex.EncodeToString(sum[:]) + `"`))
+	})
+
+	It("replays recorded interactions without the server", func() {
+		record()
+		server.Close()
+
+		client, cassette := newClient(realitydefender.CassetteReplay)
+		Expect(cassette.Unused()).To(Equal(3))
+		result, err := client.DetectFile(context.Background(), mediaPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(*result.Score).To(BeNumerically("~", 0.91))
+		Expect(cassette.Unused()).To(BeZero())
+	})
+
+	It("fails requests that were not recorded", func() {
+		record()
+
+		client, _ := newClient(realitydefender.CassetteReplay)
+		_, err := client.GetResult(context.Background(), "request-2", nil)
+		Expect(err).To(MatchError(realitydefender.ErrCassetteMiss))
+		Expect(err.Error()).To(ContainSubstring("/api/media/users/request-2"))
+
+		// Each interaction is replayed once
+		_, err = client.GetResult(context.Background(), "request-1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.GetResult(context.Background(), "request-1", nil)
+		Expect(err).To(MatchError(realitydefender.ErrCassetteMiss))
+	})
+
+	It("fails uploads of other media", func() {
+		record()
+
+		client, _ := newClient(realitydefender.CassetteReplay)
+		Expect(os.WriteFile(mediaPath, append(media, "changed"...), 0o600)).To(Succeed())
+		_, err := client.DetectFile(context.Background(), mediaPath)
+		Expect(err).To(MatchError(realitydefender.ErrCassetteMiss))
+		Expect(err.Error()).NotTo(ContainSubstring("secret"))
+	})
+
+	It("requires an existing file to replay", func() {
+		_, err := realitydefender.NewCassette(filepath.Join(dir, "missing.json"), realitydefender.CassetteReplay)
+		Expect(err).To(MatchError(realitydefender.ErrInvalidFile))
+		Expect(strings.Contains(err.Error(), "missing.json")).To(BeTrue())
+	})
+})
+
+// lateUploadTransport answers uploads before sending their body, then reads part of it and
+// closes it in the background, as transports are allowed to
+type lateUploadTransport struct{}
+
+func (lateUploadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPut {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = req.Body.Read(make([]byte, 4))
+		req.Body.Close()
+	}()
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}, Request: req}, nil
+}
@@ -4,32 +4,90 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // httpClientConfig represents configuration for the HTTP client
 type httpClientConfig struct {
-	apiKey  string
-	baseURL string
+	apiKey         string
+	baseURL        string
+	retryPolicy    RetryPolicy
+	rateLimiter    RateLimiter
+	httpClient     *http.Client
+	transport      http.RoundTripper
+	apiTimeout     time.Duration
+	uploadTimeout  time.Duration
+	middleware     []Middleware
+	logger         *slog.Logger
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+	cassette       *Cassette
 }
 
 // httpClient manages HTTP communication with the Reality Defender API
 type httpClient struct {
-	config     *httpClientConfig
-	httpClient *http.Client
+	config *httpClientConfig
+	// httpClient sends JSON API calls
+	httpClient Doer
+	// uploadClient sends presigned URL uploads, which need a much longer timeout
+	uploadClient Doer
+	logger       *slog.Logger
+	telemetry    *telemetry
 }
 
+// bodyFunc returns a fresh request body for each attempt, or errBodyNotRewindable
+// when the body cannot be replayed
+type bodyFunc func() (io.Reader, error)
+
 // newHTTPClient creates a new HTTP client for the Reality Defender API
 func newHTTPClient(config *httpClientConfig) *httpClient {
+	base := &http.Client{}
+	if config.httpClient != nil {
+		base = config.httpClient
+	}
+
+	apiClient := *base
+	uploadClient := *base
+	if config.transport != nil {
+		apiClient.Transport = config.transport
+		uploadClient.Transport = config.transport
+	}
+	if config.cassette != nil {
+		apiClient.Transport = config.cassette.transport(apiClient.Transport)
+		uploadClient.Transport = config.cassette.transport(uploadClient.Transport)
+	}
+
+	switch {
+	case config.apiTimeout > 0:
+		apiClient.Timeout = config.apiTimeout
+	case config.httpClient == nil:
+		apiClient.Timeout = DefaultAPITimeout
+	}
+
+	uploadClient.Timeout = DefaultUploadTimeout
+	if config.uploadTimeout > 0 {
+		uploadClient.Timeout = config.uploadTimeout
+	}
+
 	return &httpClient{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		config:       config,
+		httpClient:   chainMiddleware(&apiClient, config.middleware),
+		uploadClient: chainMiddleware(&uploadClient, config.middleware),
+		logger:       newLogger(config.logger),
+		telemetry:    newTelemetry(config.tracerProvider, config.meterProvider, config.propagator),
 	}
 }
 
@@ -47,27 +105,17 @@ func (c *httpClient) get(ctx context.Context, endpoint string, parameters map[st
 
 	getURL := fmt.Sprintf("%s%s%s", c.config.baseURL, endpoint, queryString)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	name := endpointName(endpoint)
+	resp, err := c.send(ctx, c.httpClient, name, http.MethodGet, getURL, nil, func(req *http.Request) {
+		req.Header.Set("X-API-KEY", c.config.apiKey)
+		req.Header.Set("Accept", "application/json")
+	})
 	if err != nil {
-		return nil, &SDKError{
-			Message: fmt.Sprintf("failed to create request: %v", err),
-			Code:    ErrorCodeUnknownError,
-		}
-	}
-
-	req.Header.Set("X-API-KEY", c.config.apiKey)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, &SDKError{
-			Message: fmt.Sprintf("request failed: %v", err),
-			Code:    ErrorCodeServerError,
-		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return handleResponse(resp)
+	return c.handleResponse(ctx, name, resp)
 }
 
 // post performs a POST request to the specified endpoint with JSON data
@@ -79,67 +127,250 @@ func (c *httpClient) post(ctx context.Context, endpoint string, data interface{}
 		return nil, &SDKError{
 			Message: fmt.Sprintf("failed to marshal JSON: %v", err),
 			Code:    ErrorCodeUnknownError,
+			Err:     err,
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, &SDKError{
-			Message: fmt.Sprintf("failed to create request: %v", err),
-			Code:    ErrorCodeUnknownError,
-		}
+	body := func() (io.Reader, error) {
+		return bytes.NewReader(jsonData), nil
 	}
 
-	req.Header.Set("X-API-KEY", c.config.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	name := endpointName(endpoint)
+	resp, err := c.send(ctx, c.httpClient, name, http.MethodPost, postURL, body, func(req *http.Request) {
+		req.ContentLength = int64(len(jsonData))
+		req.Header.Set("X-API-KEY", c.config.apiKey)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+	})
 	if err != nil {
-		return nil, &SDKError{
-			Message: fmt.Sprintf("request failed: %v", err),
-			Code:    ErrorCodeServerError,
-		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return handleResponse(resp)
+	return c.handleResponse(ctx, name, resp)
 }
 
-// put performs a PUT request to upload data to the specified URL
-func (c *httpClient) put(ctx context.Context, url string, data []byte) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(data))
-	if err != nil {
-		return &SDKError{
-			Message: fmt.Sprintf("failed to create request: %v", err),
-			Code:    ErrorCodeUnknownError,
+// put performs a PUT request streaming the body to the specified URL.
+// The size is sent as Content-Length so the body is never buffered in memory.
+func (c *httpClient) put(ctx context.Context, url string, body bodyFunc, size int64) error {
+	// Count what actually goes over the wire on the last attempt that started sending
+	var sent atomic.Pointer[atomic.Int64]
+	countedBody := func() (io.Reader, error) {
+		reader, err := body()
+		if err != nil {
+			return nil, err
 		}
+		return &attemptReader{reader: reader, current: &sent}, nil
 	}
+	defer func() {
+		var bytesSent int64
+		if count := sent.Load(); count != nil {
+			bytesSent = count.Load()
+		}
+		c.telemetry.uploadedBytes.Add(ctx, bytesSent)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("realitydefender.upload.bytes_sent", bytesSent))
+	}()
 
-	// Set content type to application/octet-stream for binary data
-	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.send(ctx, c.uploadClient, EndpointUpload, http.MethodPut, url, countedBody, func(req *http.Request) {
+		req.ContentLength = size
+		if size == 0 {
+			// An empty body must be explicit, otherwise net/http treats the length as unknown
+			req.Body = http.NoBody
+		}
 
-	// Send the request
-	resp, err := c.httpClient.Do(req)
+		// Set content type to application/octet-stream for binary data
+		req.Header.Set("Content-Type", "application/octet-stream")
+	})
 	if err != nil {
-		return &SDKError{
-			Message: fmt.Sprintf("request failed: %v", err),
-			Code:    ErrorCodeUploadFailed,
+		var sdkErr *SDKError
+		if errors.As(err, &sdkErr) && sdkErr.Code == ErrorCodeServerError {
+			sdkErr.Code = ErrorCodeUploadFailed
 		}
+		return err
 	}
 	defer resp.Body.Close()
 
 	// Check for success
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.WarnContext(ctx, "upload rejected", "status", resp.StatusCode)
 		return &SDKError{
-			Message: fmt.Sprintf("upload failed with status code %d", resp.StatusCode),
-			Code:    ErrorCodeUploadFailed,
+			Message:    fmt.Sprintf("upload failed with status code %d", resp.StatusCode),
+			Code:       ErrorCodeUploadFailed,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header),
 		}
 	}
 
 	return nil
 }
 
+// send performs a request, retrying transient failures according to the retry policy.
+// A fresh request is built for every attempt from the optional body and the prepare callback.
+// The response of the final attempt is returned whatever its status code.
+func (c *httpClient) send(ctx context.Context, client Doer, endpoint, method, requestURL string, body bodyFunc, prepare func(*http.Request)) (resp *http.Response, err error) {
+	policy := c.config.retryPolicy
+
+	ctx, span := c.telemetry.startRequestSpan(ctx, endpoint, method)
+	defer func() {
+		if resp != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		}
+		endSpan(span, err)
+	}()
+
+	reqBody, err := openBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.config.rateLimiter != nil {
+			if err := c.config.rateLimiter.Wait(ctx); err != nil {
+				return nil, &SDKError{
+					Message: fmt.Sprintf("request failed: %v", err),
+					Code:    ErrorCodeTimeout,
+					Err:     err,
+				}
+			}
+		}
+
+		attemptCtx := withRequestInfo(ctx, RequestInfo{Endpoint: endpoint, Attempt: attempt})
+		req, err := http.NewRequestWithContext(attemptCtx, method, requestURL, reqBody)
+		if err != nil {
+			return nil, &SDKError{
+				Message: fmt.Sprintf("failed to create request: %v", err),
+				Code:    ErrorCodeUnknownError,
+				Err:     err,
+			}
+		}
+		prepare(req)
+		c.telemetry.inject(ctx, req)
+		span.SetAttributes(attribute.Int("realitydefender.attempts", attempt))
+
+		c.logger.DebugContext(ctx, "request started",
+			"endpoint", endpoint,
+			"method", method,
+			"url", redactURL(requestURL),
+			"attempt", attempt,
+			"headers", redactedHeader(req.Header),
+		)
+		start := time.Now()
+
+		resp, err := client.Do(req)
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.telemetry.recordRequest(ctx, endpoint, method, status, time.Since(start))
+		if err != nil {
+			err = redactError(err)
+			c.logger.WarnContext(ctx, "request failed",
+				"endpoint", endpoint,
+				"method", method,
+				"attempt", attempt,
+				"duration", time.Since(start),
+				"error", err,
+			)
+		} else {
+			c.logger.DebugContext(ctx, "request completed",
+				"endpoint", endpoint,
+				"method", method,
+				"attempt", attempt,
+				"status", resp.StatusCode,
+				"duration", time.Since(start),
+			)
+		}
+
+		if attempt < policy.MaxAttempts && policy.shouldRetry(ctx, method, resp, err) {
+			delay := policy.backoff(attempt)
+			if resp != nil {
+				// The server knows best when it will accept requests again
+				if retryAfter := parseRetryAfter(resp.Header); retryAfter > delay {
+					delay = retryAfter
+				}
+			}
+			// Waits longer than MaxBackoff are left to the caller through SDKError.RetryAfter
+			if delay <= policy.MaxBackoff && fitsDeadline(ctx, delay) {
+				// Finish with the response before opening the next body, which may rewind the
+				// content the transport was reading. It is read rather than discarded so it can
+				// still be returned if the body cannot be replayed.
+				var respBody []byte
+				if resp != nil {
+					respBody, _ = io.ReadAll(resp.Body)
+					resp.Body.Close()
+				}
+
+				nextBody, bodyErr := openBody(body)
+				if bodyErr == nil {
+					c.telemetry.recordRetry(ctx, endpoint)
+					c.logger.InfoContext(ctx, "retrying request",
+						"endpoint", endpoint,
+						"method", method,
+						"attempt", attempt,
+						"delay", delay,
+					)
+					if err := sleepContext(ctx, delay); err != nil {
+						return nil, &SDKError{
+							Message: fmt.Sprintf("request failed: %v", err),
+							Code:    ErrorCodeTimeout,
+							Err:     err,
+						}
+					}
+					reqBody = nextBody
+					continue
+				}
+				if resp != nil {
+					resp.Body = io.NopCloser(bytes.NewReader(respBody))
+				}
+			}
+		}
+
+		if err != nil {
+			return nil, &SDKError{
+				Message: fmt.Sprintf("request failed: %v", err),
+				Code:    ErrorCodeServerError,
+				Err:     err,
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+// openBody returns the next request body, or nil for requests without one
+func openBody(body bodyFunc) (io.Reader, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	reader, err := body()
+	if err != nil {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to create request: %v", err),
+			Code:    ErrorCodeUnknownError,
+			Err:     err,
+		}
+	}
+	return reader, nil
+}
+
+// handleResponse processes an API response and logs how error responses were mapped
+func (c *httpClient) handleResponse(ctx context.Context, endpoint string, resp *http.Response) ([]byte, error) {
+	body, err := handleResponse(resp)
+	if err != nil {
+		var sdkErr *SDKError
+		if errors.As(err, &sdkErr) {
+			c.logger.DebugContext(ctx, "API error response",
+				"endpoint", endpoint,
+				"status", resp.StatusCode,
+				"code", sdkErr.Code,
+				"message", sdkErr.Message,
+			)
+		}
+	}
+	return body, err
+}
+
 // handleResponse processes HTTP responses and handles errors
 func handleResponse(resp *http.Response) ([]byte, error) {
 	body, err := io.ReadAll(resp.Body)
@@ -147,6 +378,7 @@ func handleResponse(resp *http.Response) ([]byte, error) {
 		return nil, &SDKError{
 			Message: fmt.Sprintf("failed to read response body: %v", err),
 			Code:    ErrorCodeUnknownError,
+			Err:     err,
 		}
 	}
 
@@ -183,6 +415,9 @@ func handleResponse(resp *http.Response) ([]byte, error) {
 	case http.StatusNotFound:
 		errorCode = ErrorCodeNotFound
 		errorMessage = "Resource not found"
+	case http.StatusTooManyRequests:
+		errorCode = ErrorCodeRateLimited
+		errorMessage = "Rate limit exceeded"
 	case http.StatusInternalServerError:
 		errorCode = ErrorCodeServerError
 		errorMessage = "Server error"
@@ -196,8 +431,18 @@ func handleResponse(resp *http.Response) ([]byte, error) {
 		}
 	}
 
+	requestID := resp.Header.Get("X-Request-Id")
+	if errorResp.RequestID != nil {
+		requestID = *errorResp.RequestID
+	}
+
 	return nil, &SDKError{
-		Message: errorMessage,
-		Code:    errorCode,
+		Message:    errorMessage,
+		Code:       errorCode,
+		RetryAfter: parseRetryAfter(resp.Header),
+		StatusCode: resp.StatusCode,
+		ServerCode: errorResp.Code,
+		ErrNo:      errorResp.ErrNo,
+		RequestID:  requestID,
 	}
 }
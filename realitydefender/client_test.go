@@ -5,6 +5,7 @@ import (
 	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -273,4 +274,124 @@ var _ = Describe("HTTP Client", func() {
 		Expect(result).To(BeNil())
 	})
 
+	Describe("HTTP configuration", func() {
+		resultBody := `{"requestId":"test-request-id","resultsSummary":{"status":"AUTHENTIC"},"models":[]}`
+
+		It("sends requests through a custom transport", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("X-Gateway")).To(Equal("internal"))
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(resultBody))
+			}))
+
+			var calls int32
+			var err error
+			client, err = realitydefender.New(realitydefender.Config{
+				APIKey:  "test-api-key",
+				BaseURL: server.URL,
+				Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					atomic.AddInt32(&calls, 1)
+					r.Header.Set("X-Gateway", "internal")
+					return http.DefaultTransport.RoundTrip(r)
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetResult(context.Background(), "test-endpoint", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+
+		It("uses a provided http.Client and its timeout", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(resultBody))
+			}))
+
+			var err error
+			client, err = realitydefender.New(realitydefender.Config{
+				APIKey:     "test-api-key",
+				BaseURL:    server.URL,
+				HTTPClient: &http.Client{Timeout: 50 * time.Millisecond},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetResult(context.Background(), "test-endpoint", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("request failed"))
+		})
+
+		It("keeps a provided http.Client without a timeout", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(resultBody))
+			}))
+
+			// http.Client applies its Timeout as a deadline on the request's context
+			deadlines := make(chan bool, 2)
+			newClient := func(httpClient *http.Client) *realitydefender.Client {
+				c, err := realitydefender.New(realitydefender.Config{
+					APIKey:     "test-api-key",
+					BaseURL:    server.URL,
+					HTTPClient: httpClient,
+					Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+						_, ok := r.Context().Deadline()
+						deadlines <- ok
+						return http.DefaultTransport.RoundTrip(r)
+					}),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return c
+			}
+
+			_, err := newClient(&http.Client{}).GetResult(context.Background(), "test-endpoint", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadlines).To(Receive(BeFalse()))
+
+			_, err = newClient(nil).GetResult(context.Background(), "test-endpoint", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadlines).To(Receive(BeTrue()))
+		})
+
+		It("applies separate timeouts to API calls and uploads", func() {
+			mux := http.NewServeMux()
+			server = httptest.NewServer(mux)
+			mux.HandleFunc("/api/files/aws-presigned", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"response":{"signedUrl":"` + server.URL + `/upload"},"mediaId":"test-media-id","requestId":"test-request-id"}`))
+			})
+			mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			newClient := func(uploadTimeout time.Duration) *realitydefender.Client {
+				c, err := realitydefender.New(realitydefender.Config{
+					APIKey:        "test-api-key",
+					BaseURL:       server.URL,
+					APITimeout:    time.Second,
+					UploadTimeout: uploadTimeout,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return c
+			}
+			options := realitydefender.UploadOptions{FileName: "clip.txt", Data: []byte("content")}
+
+			_, err := newClient(50*time.Millisecond).Upload(context.Background(), options)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to upload to signed URL"))
+
+			result, err := newClient(time.Second).Upload(context.Background(), options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequestID).To(Equal("test-request-id"))
+		})
+	})
 })
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,128 @@
+package realitydefender
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ModelDetails holds the model-specific details the API reports in a model's data. Which
+// fields are set depends on the model and the media type; use ModelResult.Data or
+// ModelResult.DecodeData for details of models not covered here.
+type ModelDetails struct {
+	// Frames scores individual video frames
+	Frames []FrameScore `json:"frames,omitempty"`
+	// Segments scores time ranges of video
+	Segments []SegmentScore `json:"segments,omitempty"`
+	// Faces locates the faces analyzed in images and video frames
+	Faces []FaceRegion `json:"faces,omitempty"`
+	// AudioRanges scores time ranges of audio
+	AudioRanges []AudioRange `json:"audioRanges,omitempty"`
+	// ErrorCode explains why the model could not evaluate the media, when it could not
+	ErrorCode string `json:"errorCode,omitempty"`
+}
+
+// FrameScore is the score of one video frame
+type FrameScore struct {
+	// Frame is the frame index, starting at 0
+	Frame int `json:"frame"`
+	// Time is the offset of the frame in seconds
+	Time float64 `json:"time"`
+	// Score is the manipulation score of the frame (0-1)
+	Score float64 `json:"score"`
+}
+
+// SegmentScore is the score of a time range of video
+type SegmentScore struct {
+	// Start is the offset of the start of the segment in seconds
+	Start float64 `json:"start"`
+	// End is the offset of the end of the segment in seconds
+	End float64 `json:"end"`
+	// Score is the manipulation score of the segment (0-1)
+	Score float64 `json:"score"`
+}
+
+// FaceRegion is a face found in an image or video frame, with its bounding box in pixels
+type FaceRegion struct {
+	// Frame is the index of the video frame the face was found in, 0 for images
+	Frame int `json:"frame"`
+	// X is the distance of the left edge of the box from the left of the media
+	X float64 `json:"x"`
+	// Y is the distance of the top edge of the box from the top of the media
+	Y float64 `json:"y"`
+	// Width is the width of the box
+	Width float64 `json:"width"`
+	// Height is the height of the box
+	Height float64 `json:"height"`
+	// Score is the manipulation score of the face (0-1)
+	Score float64 `json:"score"`
+}
+
+// AudioRange is the score of a time range of audio
+type AudioRange struct {
+	// Start is the offset of the start of the range in seconds
+	Start float64 `json:"start"`
+	// End is the offset of the end of the range in seconds
+	End float64 `json:"end"`
+	// Score is the manipulation score of the range (0-1)
+	Score float64 `json:"score"`
+}
+
+// Details decodes the model's data into ModelDetails, with scores normalized to 0-1. The
+// scores of one model share a scale: they are all read as 0-100 when any is above 1. It
+// returns nil without an error when the model reported no data.
+func (m ModelResult) Details() (*ModelDetails, error) {
+	if !hasData(m.Data) {
+		return nil, nil
+	}
+
+	var details ModelDetails
+	if err := m.DecodeData(&details); err != nil {
+		return nil, err
+	}
+
+	var scores []*float64
+	for i := range details.Frames {
+		scores = append(scores, &details.Frames[i].Score)
+	}
+	for i := range details.Segments {
+		scores = append(scores, &details.Segments[i].Score)
+	}
+	for i := range details.Faces {
+		scores = append(scores, &details.Faces[i].Score)
+	}
+	for i := range details.AudioRanges {
+		scores = append(scores, &details.AudioRanges[i].Score)
+	}
+	for _, score := range scores {
+		if *score > 1 {
+			for _, score := range scores {
+				*score /= 100.0
+			}
+			break
+		}
+	}
+	return &details, nil
+}
+
+// DecodeData decodes the model's raw data into v, for models whose details Details does not
+// cover. Scores are decoded as the API reports them.
+func (m ModelResult) DecodeData(v interface{}) error {
+	if !hasData(m.Data) {
+		return nil
+	}
+	if err := json.Unmarshal(m.Data, v); err != nil {
+		return &SDKError{
+			Message: fmt.Sprintf("invalid data for model %s: %v", m.Name, err),
+			Code:    ErrorCodeServerError,
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// hasData reports whether raw model data holds a value
+func hasData(data json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null"))
+}
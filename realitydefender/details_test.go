@@ -0,0 +1,119 @@
+package realitydefender_test
+
+import (
+	"encoding/json"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Model details", func() {
+	format := func(responseJSON string) *realitydefender.DetectionResult {
+		var response realitydefender.MediaResponse
+		Expect(json.Unmarshal([]byte(responseJSON), &response)).To(Succeed())
+		return realitydefender.FormatResult(&response)
+	}
+
+	It("keeps the data and code of each model", func() {
+		result := format(`{
+			"requestId": "req-1",
+			"resultsSummary": {"status": "FAKE", "metadata": {"finalScore": 91}},
+			"models": [
+				{"name": "video", "status": "FAKE", "finalScore": 93, "data": {
+					"frames": [{"frame": 0, "time": 0, "score": 12}, {"frame": 30, "time": 1, "score": 97}],
+					"segments": [{"start": 0.5, "end": 1.5, "score": 90}],
+					"faces": [{"frame": 30, "x": 10, "y": 20, "width": 64, "height": 80, "score": 97}]
+				}},
+				{"name": "audio", "status": "AUTHENTIC", "finalScore": 8, "data": {
+					"audioRanges": [{"start": 0, "end": 2.5, "score": 8}]
+				}},
+				{"name": "broken", "status": "UNABLE_TO_EVALUATE", "code": "media-too-short", "data": {"errorCode": "too_short"}},
+				{"name": "empty", "status": "NOT_APPLICABLE", "data": null}
+			]
+		}`)
+		Expect(result.Models).To(HaveLen(4))
+
+		video, err := result.Models[0].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(video.Frames).To(Equal([]realitydefender.FrameScore{{Frame: 0, Time: 0, Score: 0.12}, {Frame: 30, Time: 1, Score: 0.97}}))
+		Expect(video.Segments).To(Equal([]realitydefender.SegmentScore{{Start: 0.5, End: 1.5, Score: 0.9}}))
+		Expect(video.Faces).To(Equal([]realitydefender.FaceRegion{{Frame: 30, X: 10, Y: 20, Width: 64, Height: 80, Score: 0.97}}))
+
+		audio, err := result.Models[1].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(audio.AudioRanges).To(Equal([]realitydefender.AudioRange{{Start: 0, End: 2.5, Score: 0.08}}))
+
+		broken, err := result.Models[2].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Models[2].Code).To(Equal("media-too-short"))
+		Expect(broken.ErrorCode).To(Equal("too_short"))
+
+		empty, err := result.Models[3].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(empty).To(BeNil())
+		Expect(result.Models[3].Data).To(BeNil())
+	})
+
+	It("reads the scores of a model on one scale", func() {
+		result := format(`{"requestId": "req-1", "resultsSummary": {"status": "FAKE"}, "models": [
+			{"name": "video", "status": "FAKE", "data": {"frames": [{"frame": 0, "score": 0.5}, {"frame": 1, "score": 80}]}},
+			{"name": "image", "status": "FAKE", "data": {"faces": [{"score": 0.5}, {"score": 1}]}}
+		]}`)
+
+		video, err := result.Models[0].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(video.Frames[0].Score).To(BeNumerically("~", 0.005))
+		Expect(video.Frames[1].Score).To(BeNumerically("~", 0.8))
+
+		image, err := result.Models[1].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(image.Faces[0].Score).To(Equal(0.5))
+		Expect(image.Faces[1].Score).To(Equal(1.0))
+	})
+
+	It("passes the data through as the API sent it", func() {
+		result := format(`{"requestId": "req-1", "resultsSummary": {"status": "FAKE"}, "models": [
+			{"name": "custom", "status": "FAKE", "data": {"z": 1, "id": 9007199254740993}}
+		]}`)
+
+		Expect(string(result.Models[0].Data)).To(Equal(`{"z": 1, "id": 9007199254740993}`))
+	})
+
+	It("decodes the raw data of other models", func() {
+		result := format(`{"requestId": "req-1", "resultsSummary": {"status": "FAKE"}, "models": [
+			{"name": "custom", "status": "FAKE", "data": {"heatmap": "https://example.com/heatmap.png", "layers": 3}}
+		]}`)
+
+		var custom struct {
+			Heatmap string `json:"heatmap"`
+			Layers  int    `json:"layers"`
+		}
+		Expect(result.Models[0].DecodeData(&custom)).To(Succeed())
+		Expect(custom.Heatmap).To(Equal("https://example.com/heatmap.png"))
+		Expect(custom.Layers).To(Equal(3))
+	})
+
+	It("fails on data of an unexpected shape", func() {
+		model := realitydefender.ModelResult{Name: "video", Data: json.RawMessage(`{"frames": "not a list"}`)}
+		_, err := model.Details()
+		Expect(err).To(MatchError(realitydefender.ErrServerError))
+		Expect(err.Error()).To(ContainSubstring("video"))
+	})
+
+	It("keeps the data when results are stored as JSON", func() {
+		result := format(`{"requestId": "req-1", "resultsSummary": {"status": "FAKE"}, "models": [
+			{"name": "audio", "status": "FAKE", "code": "ok", "data": {"audioRanges": [{"start": 1, "end": 2, "score": 0.9}]}}
+		]}`)
+
+		data, err := json.Marshal(result)
+		Expect(err).NotTo(HaveOccurred())
+		var stored realitydefender.DetectionResult
+		Expect(json.Unmarshal(data, &stored)).To(Succeed())
+
+		Expect(stored.Models[0].Code).To(Equal("ok"))
+		details, err := stored.Models[0].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(details.AudioRanges).To(Equal([]realitydefender.AudioRange{{Start: 1, End: 2, Score: 0.9}}))
+	})
+})
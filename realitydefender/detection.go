@@ -1,14 +1,20 @@
 package realitydefender
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -78,9 +84,32 @@ type MediaResponse struct {
 		FinalScore *float64    `json:"finalScore"`
 		Data       interface{} `json:"data"`
 		Code       string      `json:"code,omitempty"`
+		// RawData is Data as the API sent it, set when the response is decoded from JSON
+		RawData json.RawMessage `json:"-"`
 	} `json:"models"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler, keeping each model's data as sent in RawData
+func (r *MediaResponse) UnmarshalJSON(data []byte) error {
+	type plain MediaResponse
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Models []struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"models"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for i := range r.Models {
+		r.Models[i].RawData = raw.Models[i].Data
+	}
+	return nil
+}
+
 // AllMediaResponse represents a paginated response containing a list of media and related metadata.
 type AllMediaResponse struct {
 	TotalItems            int             `json:"totalItems"`
@@ -109,39 +138,207 @@ func getSignedURL(ctx context.Context, client *httpClient, fileName string) (*Si
 		return nil, &SDKError{
 			Message: fmt.Sprintf("failed to parse signed URL response: %v", err),
 			Code:    ErrorCodeUnknownError,
+			Err:     err,
 		}
 	}
 
 	return &response, nil
 }
 
-// uploadToSignedURL uploads file content to a signed URL
-func uploadToSignedURL(ctx context.Context, client *httpClient, signedURL string, filePath string) error {
-	// Read file content
-	fileContent, err := os.ReadFile(filePath)
+// uploadToSignedURL streams content to a signed URL
+func uploadToSignedURL(ctx context.Context, client *httpClient, signedURL string, body bodyFunc, size int64) error {
+	err := client.put(ctx, signedURL, body, size)
 	if err != nil {
-		return &SDKError{
+		return wrapError(err, "failed to upload to signed URL", ErrorCodeUploadFailed)
+	}
+
+	return nil
+}
+
+// validateUpload checks the media type against SupportedFileTypes and its size limit.
+// The type is taken from the content header when it is recognized and from the name's
+// extension otherwise; audio recognized from a few header bits does not override .txt, and
+// an equivalent extension such as .m4a for MP4 content is kept along with its size limit.
+// It returns the file name to upload under, which gains the detected extension when the
+// name has none.
+func validateUpload(name string, header []byte, size int64, rejectMismatch bool) (string, error) {
+	// Get file extension
+	fileExtension := strings.ToLower(filepath.Ext(name))
+	fileName := filepath.Base(name)
+
+	// Prefer the type found in the content over the one claimed by the name, unless the match
+	// is weak and the name claims text, which can start with the same bytes by chance
+	if detected, weak := detectFileType(header); detected != "" && !(weak && fileExtension == textFileType) {
+		if fileExtension == "" {
+			fileName += detected
+			fileExtension = detected
+		} else if !sameFileType(fileExtension, detected) {
+			if rejectMismatch {
+				return "", &SDKError{
+					Message: fmt.Sprintf("File content does not match extension: %s contains %s data", name, detected),
+					Code:    ErrorCodeInvalidFile,
+				}
+			}
+			fileExtension = detected
+		}
+		// An equivalent extension is kept, since it can tell audio from video in a shared
+		// container format
+	}
+
+	// Check if file type is supported
+	sizeLimit := fileSizeLimit(fileExtension)
+	if sizeLimit == 0 {
+		return "", &SDKError{
+			Message: fmt.Sprintf("Unsupported file type: %s", fileExtension),
+			Code:    ErrorCodeInvalidFile,
+		}
+	}
+
+	// Check file size
+	if size > sizeLimit {
+		return "", &SDKError{
+			Message: fmt.Sprintf("File too large to upload: %s", name),
+			Code:    ErrorCodeFileTooLarge,
+		}
+	}
+
+	return fileName, nil
+}
+
+// uploadReader validates and streams size bytes read from r to Reality Defender under the given name
+func uploadReader(ctx context.Context, client *httpClient, name string, r io.Reader, size int64, options UploadOptions) (result *UploadResult, err error) {
+	ctx, span := client.telemetry.startSpan(ctx, "Upload",
+		attribute.String("realitydefender.file.name", filepath.Base(name)),
+		attribute.Int64("realitydefender.file.size", size),
+	)
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.String("realitydefender.request_id", result.RequestID))
+		}
+		endSpan(span, err)
+	}()
+
+	if name == "" {
+		return nil, &SDKError{
+			Message: "file name is required",
+			Code:    ErrorCodeInvalidFile,
+		}
+	}
+	if r == nil {
+		return nil, &SDKError{
+			Message: "reader is required",
+			Code:    ErrorCodeInvalidFile,
+		}
+	}
+	if size < 0 {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("invalid size: %d", size),
+			Code:    ErrorCodeInvalidFile,
+		}
+	}
+
+	// Remember where seekable content starts so a failed upload can be retried
+	seeker, seekable := r.(io.Seeker)
+	var start int64
+	if seekable {
+		var err error
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false
+		}
+	}
+
+	// Peek at the header without consuming it so the full content is still streamed
+	body := bufio.NewReaderSize(io.LimitReader(r, size), sniffLen)
+	header, err := body.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, &SDKError{
 			Message: fmt.Sprintf("failed to read file: %v", err),
 			Code:    ErrorCodeInvalidFile,
+			Err:     err,
 		}
 	}
 
-	// Upload to signed URL
-	err = client.put(ctx, signedURL, fileContent)
+	fileName, err := validateUpload(name, header, size, options.RejectTypeMismatch)
+	if err != nil {
+		client.logger.DebugContext(ctx, "upload rejected by validation", "name", name, "size", size, "error", err)
+		return nil, err
+	}
+	client.logger.InfoContext(ctx, "uploading media", "file_name", fileName, "size", size)
+
+	// Get signed URL
+	signedURLResponse, err := getSignedURL(ctx, client, fileName)
 	if err != nil {
-		return &SDKError{
-			Message: fmt.Sprintf("failed to upload to signed URL: %v", err),
-			Code:    ErrorCodeUploadFailed,
+		return nil, err
+	}
+
+	// Stream exactly size bytes; a short reader surfaces as an upload error
+	opened := false
+	openBody := func() (io.Reader, error) {
+		var reader io.Reader = body
+		if opened {
+			if !seekable {
+				return nil, errBodyNotRewindable
+			}
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			reader = io.LimitReader(r, size)
 		}
+		opened = true
+
+		if options.OnProgress != nil {
+			reader = newProgressReader(reader, fileName, size, options.OnProgress)
+		}
+		return reader, nil
 	}
 
-	return nil
+	err = uploadToSignedURL(ctx, client, signedURLResponse.Response.SignedURL, openBody, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return result for tracking
+	return &UploadResult{
+		RequestID: signedURLResponse.RequestID,
+		MediaID:   signedURLResponse.MediaID,
+	}, nil
 }
 
-// uploadFile uploads a file to Reality Defender for analysis
+// uploadFile uploads media from whichever source is set in the options
 func uploadFile(ctx context.Context, client *httpClient, options UploadOptions) (*UploadResult, error) {
+	sources := 0
+	if options.Data != nil {
+		sources++
+	}
+	if options.Reader != nil {
+		sources++
+	}
+	if options.FilePath != "" || options.FS != nil {
+		sources++
+	}
+	if sources > 1 {
+		return nil, &SDKError{
+			Message: "only one of FilePath, Data or Reader may be set",
+			Code:    ErrorCodeInvalidRequest,
+		}
+	}
+
+	switch {
+	case options.Data != nil:
+		return uploadBytes(ctx, client, options.FileName, options.Data, options)
+	case options.Reader != nil:
+		return uploadFromReader(ctx, client, options.FileName, options.Reader, options.Size, options)
+	case options.FS != nil:
+		return uploadFromFS(ctx, client, options.FS, options.FilePath, options)
+	default:
+		return uploadFromPath(ctx, client, options.FilePath, options)
+	}
+}
+
+// uploadFromPath uploads a file from the local filesystem
+func uploadFromPath(ctx context.Context, client *httpClient, filePath string, options UploadOptions) (*UploadResult, error) {
 	// Validate file path
-	if options.FilePath == "" {
+	if filePath == "" {
 		return nil, &SDKError{
 			Message: "file path is required",
 			Code:    ErrorCodeInvalidFile,
@@ -149,70 +346,113 @@ func uploadFile(ctx context.Context, client *httpClient, options UploadOptions)
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(options.FilePath); os.IsNotExist(err) {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &SDKError{
+				Message: fmt.Sprintf("file not found: %s", filePath),
+				Code:    ErrorCodeInvalidFile,
+				Err:     err,
+			}
+		}
 		return nil, &SDKError{
-			Message: fmt.Sprintf("file not found: %s", options.FilePath),
+			Message: fmt.Sprintf("failed to read file: %v", err),
 			Code:    ErrorCodeInvalidFile,
+			Err:     err,
 		}
 	}
 
-	// Get file size
-	fileInfo, _ := os.Stat(options.FilePath)
-	fileSize := fileInfo.Size()
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to read file: %v", err),
+			Code:    ErrorCodeInvalidFile,
+			Err:     err,
+		}
+	}
+	defer file.Close()
 
-	// Get file extension
-	fileExtension := strings.ToLower(filepath.Ext(options.FilePath))
-
-	// Find size limit for the file extension
-	var fileSizeLimit int64 = 0
-	for _, fileType := range SupportedFileTypes {
-		for _, ext := range fileType.Extensions {
-			if ext == fileExtension {
-				fileSizeLimit = fileType.SizeLimit
-				break
+	return uploadReader(ctx, client, filePath, file, fileInfo.Size(), options)
+}
+
+// uploadFromFS uploads a file read from an fs.FS
+func uploadFromFS(ctx context.Context, client *httpClient, fsys fs.FS, filePath string, options UploadOptions) (*UploadResult, error) {
+	if filePath == "" {
+		return nil, &SDKError{
+			Message: "file path is required",
+			Code:    ErrorCodeInvalidFile,
+		}
+	}
+
+	fileInfo, err := fs.Stat(fsys, filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, &SDKError{
+				Message: fmt.Sprintf("file not found: %s", filePath),
+				Code:    ErrorCodeInvalidFile,
+				Err:     err,
 			}
 		}
-		if fileSizeLimit != 0 {
-			break
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to read file: %v", err),
+			Code:    ErrorCodeInvalidFile,
+			Err:     err,
 		}
 	}
-
-	// Check if file type is supported
-	if fileSizeLimit == 0 {
+	if fileInfo.IsDir() {
 		return nil, &SDKError{
-			Message: fmt.Sprintf("Unsupported file type: %s", fileExtension),
+			Message: fmt.Sprintf("not a file: %s", filePath),
 			Code:    ErrorCodeInvalidFile,
 		}
 	}
 
-	// Check file size
-	if fileSize > fileSizeLimit {
+	file, err := fsys.Open(filePath)
+	if err != nil {
 		return nil, &SDKError{
-			Message: fmt.Sprintf("File too large to upload: %s", options.FilePath),
-			Code:    ErrorCodeFileTooLarge,
+			Message: fmt.Sprintf("failed to read file: %v", err),
+			Code:    ErrorCodeInvalidFile,
+			Err:     err,
 		}
 	}
+	defer file.Close()
 
-	// Get the filename
-	fileName := filepath.Base(options.FilePath)
+	return uploadReader(ctx, client, filePath, file, fileInfo.Size(), options)
+}
 
-	// Get signed URL
-	signedURLResponse, err := getSignedURL(ctx, client, fileName)
-	if err != nil {
-		return nil, err
+// uploadBytes uploads in-memory content under the given file name
+func uploadBytes(ctx context.Context, client *httpClient, fileName string, data []byte, options UploadOptions) (*UploadResult, error) {
+	return uploadReader(ctx, client, fileName, bytes.NewReader(data), int64(len(data)), options)
+}
+
+// uploadFromReader uploads reader content, discovering the size when it is not given
+func uploadFromReader(ctx context.Context, client *httpClient, fileName string, r io.Reader, size int64, options UploadOptions) (*UploadResult, error) {
+	if size <= 0 {
+		size = readerSize(r)
+	}
+	if size < 0 {
+		return nil, &SDKError{
+			Message: "size is required for readers of unknown length",
+			Code:    ErrorCodeInvalidFile,
+		}
 	}
 
-	// Upload to signed URL
-	err = uploadToSignedURL(ctx, client, signedURLResponse.Response.SignedURL, options.FilePath)
-	if err != nil {
-		return nil, err
+	return uploadReader(ctx, client, fileName, r, size, options)
+}
+
+// readerSize reports the remaining length of readers that expose it, or -1 if unknown
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		// bytes.Reader, bytes.Buffer and strings.Reader
+		return int64(v.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		// os.File and fs.File; assumes the reader is positioned at the start
+		if info, err := v.Stat(); err == nil && info.Mode().IsRegular() {
+			return info.Size()
+		}
 	}
 
-	// Return result for tracking
-	return &UploadResult{
-		RequestID: signedURLResponse.RequestID,
-		MediaID:   signedURLResponse.MediaID,
-	}, nil
+	return -1
 }
 
 // FormatResult formats the raw API response into a user-friendly result
@@ -220,12 +460,8 @@ func FormatResult(response *MediaResponse) *DetectionResult {
 	// Extract the overall status and score
 	requestID := response.RequestID
 
-	status := response.ResultsSummary.Status
-
 	// Replace FAKE with MANIPULATED
-	if status == "FAKE" {
-		status = "MANIPULATED"
-	}
+	status := ParseStatus(response.ResultsSummary.Status)
 
 	// Normalize score from 0-100 to 0-1 if needed
 	var score *float64
@@ -252,16 +488,18 @@ func FormatResult(response *MediaResponse) *DetectionResult {
 			modelScore = &normalizedModelScore
 		}
 
-		// Replace FAKE with MANIPULATED in model status
-		modelStatus := model.Status
-		if modelStatus == "FAKE" {
-			modelStatus = "MANIPULATED"
+		// Keep the model's details as sent, which ModelResult.Details decodes
+		var data json.RawMessage
+		if hasData(model.RawData) {
+			data = model.RawData
 		}
 
 		models = append(models, ModelResult{
 			Name:   model.Name,
-			Status: modelStatus,
+			Status: ParseStatus(model.Status),
 			Score:  modelScore,
+			Code:   model.Code,
+			Data:   data,
 		})
 	}
 
@@ -289,18 +527,58 @@ func formatResults(response *AllMediaResponse) *DetectionResultList {
 	}
 }
 
-// getDetectionResult gets the detection result for a specific request ID
-func getDetectionResult(ctx context.Context, client *httpClient, requestID string, options GetResultOptions) (*DetectionResult, error) {
+// fetchDetectionResult performs a single poll for the detection result of a request ID
+func fetchDetectionResult(ctx context.Context, client *httpClient, requestID string, attempt int) (result *DetectionResult, err error) {
+	ctx, span := client.telemetry.startSpan(ctx, "poll",
+		attribute.String("realitydefender.request_id", requestID),
+		attribute.Int("realitydefender.poll.attempt", attempt),
+	)
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.String("realitydefender.status", string(result.Status)))
+		}
+		endSpan(span, err)
+	}()
+
+	// Get the result
+	responseData, err := client.get(ctx, fmt.Sprintf("%s/%s", mediaResultEndpoint, requestID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	var mediaResponse MediaResponse
+	if err := json.Unmarshal(responseData, &mediaResponse); err != nil {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to parse result response: %v", err),
+			Code:    ErrorCodeUnknownError,
+			Err:     err,
+		}
+	}
+
+	// Format the response into a DetectionResult
+	return FormatResult(&mediaResponse), nil
+}
+
+// getDetectionResult polls for a result until it is final or the attempts run out,
+// reporting each poll to onAttempt when it is set
+func getDetectionResult(ctx context.Context, client *httpClient, requestID string, options GetResultOptions, onAttempt func(PollAttempt)) (result *DetectionResult, err error) {
+	ctx, span := client.telemetry.startSpan(ctx, "GetResult", attribute.String("realitydefender.request_id", requestID))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.String("realitydefender.status", string(result.Status)))
+		}
+		endSpan(span, err)
+	}()
+
 	// Set default values if not provided
 	maxAttempts := options.MaxAttempts
 	if maxAttempts <= 0 {
 		maxAttempts = defaultMaxAttempts
 	}
 
-	pollingInterval := options.PollingInterval
-	if pollingInterval <= 0 {
-		pollingInterval = DefaultPollingInterval
-	}
+	strategy := pollStrategy(options.Strategy, options.Interval, options.PollingInterval)
+	began := time.Now()
 
 	// Keep track of attempts
 	attempt := 0
@@ -308,7 +586,15 @@ func getDetectionResult(ctx context.Context, client *httpClient, requestID strin
 	// Loop until we get a result or reach max attempts
 	for attempt < maxAttempts {
 		// Get the result
-		responseData, err := client.get(ctx, fmt.Sprintf("%s/%s", mediaResultEndpoint, requestID), nil)
+		start := time.Now()
+		result, err := fetchDetectionResult(ctx, client, requestID, attempt+1)
+		if onAttempt != nil {
+			report := PollAttempt{RequestID: requestID, Attempt: attempt + 1, Err: err, Duration: time.Since(start)}
+			if result != nil {
+				report.Status = result.Status
+			}
+			onAttempt(report)
+		}
 
 		// Handle specific error types
 		if err != nil {
@@ -316,74 +602,60 @@ func getDetectionResult(ctx context.Context, client *httpClient, requestID strin
 			if errors.As(err, &sdkErr) && sdkErr.Code == ErrorCodeNotFound {
 				// If resource not found, wait and try again
 				attempt++
+				client.logger.DebugContext(ctx, "result not found yet",
+					"request_id", requestID,
+					"attempt", attempt,
+					"max_attempts", maxAttempts,
+				)
 
 				// Check if we've reached max attempts
 				if attempt >= maxAttempts {
 					return nil, err
 				}
 
-				// Wait for the polling interval before trying again
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(time.Duration(pollingInterval) * time.Millisecond):
-					continue
+				// Wait before trying again
+				state := PollState{RequestID: requestID, MediaType: options.MediaType, Attempt: attempt, Elapsed: time.Since(began)}
+				if err := sleepContext(ctx, strategy.NextDelay(state)); err != nil {
+					return nil, err
 				}
+				continue
 			}
 
 			// Any other error, return immediately
 			return nil, err
 		}
 
-		// Parse the response
-		var mediaResponse MediaResponse
-		if err := json.Unmarshal(responseData, &mediaResponse); err != nil {
-			return nil, &SDKError{
-				Message: fmt.Sprintf("failed to parse result response: %v", err),
-				Code:    ErrorCodeUnknownError,
-			}
-		}
-
-		// Format the response into a DetectionResult
-		result := FormatResult(&mediaResponse)
-
-		// Check if we have a final result or still analyzing
-		isAnalyzing := result.Status == "ANALYZING"
-
-		// Also check if all models are still analyzing or not applicable
-		allModelsAnalyzingOrNA := true
-		hasAnalyzingModels := false
-
-		for _, model := range result.Models {
-			if model.Status != "ANALYZING" && model.Status != "NOT_APPLICABLE" {
-				allModelsAnalyzingOrNA = false
-				break
-			}
-			if model.Status == "ANALYZING" {
-				hasAnalyzingModels = true
-			}
-		}
-
-		// Continue polling if overall status is ANALYZING or
-		// if all models are either ANALYZING or NOT_APPLICABLE and at least one is ANALYZING
-		if isAnalyzing || (allModelsAnalyzingOrNA && hasAnalyzingModels) {
+		// Continue polling while the result is not final
+		if !result.IsFinal() {
 			attempt++
+			client.logger.DebugContext(ctx, "result still analyzing",
+				"request_id", requestID,
+				"attempt", attempt,
+				"max_attempts", maxAttempts,
+			)
 
 			// Check if we've reached max attempts
 			if attempt >= maxAttempts {
+				client.logger.WarnContext(ctx, "polling attempts exhausted while analyzing",
+					"request_id", requestID,
+					"attempts", attempt,
+				)
 				return result, nil
 			}
 
-			// Wait for the polling interval before trying again
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(time.Duration(pollingInterval) * time.Millisecond):
-				continue
+			// Wait before trying again
+			state := PollState{RequestID: requestID, MediaType: options.MediaType, Attempt: attempt, Elapsed: time.Since(began)}
+			if err := sleepContext(ctx, strategy.NextDelay(state)); err != nil {
+				return nil, err
 			}
+			continue
 		}
 
 		// We have a final result
+		client.logger.DebugContext(ctx, "result ready",
+			"request_id", requestID,
+			"status", result.Status,
+		)
 		return result, nil
 	}
 
@@ -395,13 +667,24 @@ func getDetectionResult(ctx context.Context, client *httpClient, requestID strin
 }
 
 // getDetectionResults gets the detection result stored in the platform
-func getDetectionResults(ctx context.Context, client *httpClient, pageNumber *int, size *int, name *string, startDate *time.Time, endDate *time.Time, options GetResultOptions) (*DetectionResultList, error) {
+func getDetectionResults(ctx context.Context, client *httpClient, pageNumber *int, size *int, name *string, startDate *time.Time, endDate *time.Time, options GetResultOptions) (results *DetectionResultList, err error) {
 	// Set default values if not provided
 	if pageNumber == nil {
 		defaultPageNumber := 0
 		pageNumber = &defaultPageNumber
 	}
 
+	ctx, span := client.telemetry.startSpan(ctx, "GetResults", attribute.Int("realitydefender.page", *pageNumber))
+	defer func() {
+		if results != nil {
+			span.SetAttributes(
+				attribute.Int("realitydefender.page.items", results.CurrentPageItemsCount),
+				attribute.Int("realitydefender.total_pages", results.TotalPages),
+			)
+		}
+		endSpan(span, err)
+	}()
+
 	var parameters = make(map[string]string)
 
 	if size == nil {
@@ -427,10 +710,8 @@ func getDetectionResults(ctx context.Context, client *httpClient, pageNumber *in
 		maxAttempts = defaultMaxAttempts
 	}
 
-	pollingInterval := options.PollingInterval
-	if pollingInterval <= 0 {
-		pollingInterval = DefaultPollingInterval
-	}
+	strategy := pollStrategy(options.Strategy, options.Interval, options.PollingInterval)
+	began := time.Now()
 
 	// Keep track of attempts
 	attempt := 0
@@ -452,13 +733,12 @@ func getDetectionResults(ctx context.Context, client *httpClient, pageNumber *in
 					return nil, err
 				}
 
-				// Wait for the polling interval before trying again
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(time.Duration(pollingInterval) * time.Millisecond):
-					continue
+				// Wait before trying again
+				state := PollState{MediaType: options.MediaType, Attempt: attempt, Elapsed: time.Since(began)}
+				if err := sleepContext(ctx, strategy.NextDelay(state)); err != nil {
+					return nil, err
 				}
+				continue
 			}
 
 			// Any other error, return immediately
@@ -471,6 +751,7 @@ func getDetectionResults(ctx context.Context, client *httpClient, pageNumber *in
 			return nil, &SDKError{
 				Message: fmt.Sprintf("failed to parse result response: %v", err),
 				Code:    ErrorCodeUnknownError,
+				Err:     err,
 			}
 		}
 
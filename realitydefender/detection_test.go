@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"testing/fstest"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -75,18 +78,18 @@ var _ = Describe("Detection Functions", func() {
 			result := realitydefender.FormatResult(&mediaResponse)
 
 			// Verify the result
-			Expect(result.Status).To(Equal("MANIPULATED"))
+			Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Score).To(BeNumerically("~", 0.875, 0.001))
 			Expect(len(result.Models)).To(Equal(2))
 
 			// Check first model
 			Expect(result.Models[0].Name).To(Equal("model1"))
-			Expect(result.Models[0].Status).To(Equal("MANIPULATED"))
+			Expect(result.Models[0].Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Models[0].Score).To(BeNumerically("~", 0.902, 0.001))
 
 			// Check second model
 			Expect(result.Models[1].Name).To(Equal("model2"))
-			Expect(result.Models[1].Status).To(Equal("AUTHENTIC"))
+			Expect(result.Models[1].Status).To(Equal(realitydefender.StatusAuthentic))
 			Expect(*result.Models[1].Score).To(BeNumerically("~", 0.358, 0.001))
 		})
 
@@ -130,18 +133,18 @@ var _ = Describe("Detection Functions", func() {
 			result := realitydefender.FormatResult(&mediaResponse)
 
 			// Verify the result
-			Expect(result.Status).To(Equal("AUTHENTIC"))
+			Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
 			Expect(*result.Score).To(BeNumerically("~", 0.25, 0.001))
 			Expect(len(result.Models)).To(Equal(2))
 
 			// Check first model
 			Expect(result.Models[0].Name).To(Equal("model1"))
-			Expect(result.Models[0].Status).To(Equal("AUTHENTIC"))
+			Expect(result.Models[0].Status).To(Equal(realitydefender.StatusAuthentic))
 			Expect(*result.Models[0].Score).To(BeNumerically("~", 0.15, 0.001))
 
 			// Check second model
 			Expect(result.Models[1].Name).To(Equal("model2"))
-			Expect(result.Models[1].Status).To(Equal("MANIPULATED"))
+			Expect(result.Models[1].Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Models[1].Score).To(BeNumerically("~", 0.92, 0.001))
 		})
 
@@ -185,18 +188,18 @@ var _ = Describe("Detection Functions", func() {
 			result := realitydefender.FormatResult(&mediaResponse)
 
 			// Verify the result
-			Expect(result.Status).To(Equal("ANALYZING"))
+			Expect(result.Status).To(Equal(realitydefender.StatusAnalyzing))
 			Expect(result.Score).To(BeNil())
 			Expect(len(result.Models)).To(Equal(2))
 
 			// Check first model
 			Expect(result.Models[0].Name).To(Equal("model1"))
-			Expect(result.Models[0].Status).To(Equal("ANALYZING"))
+			Expect(result.Models[0].Status).To(Equal(realitydefender.StatusAnalyzing))
 			Expect(result.Models[0].Score).To(BeNil())
 
 			// Check second model
 			Expect(result.Models[1].Name).To(Equal("model2"))
-			Expect(result.Models[1].Status).To(Equal("NOT_APPLICABLE"))
+			Expect(result.Models[1].Status).To(Equal(realitydefender.StatusNotApplicable))
 			Expect(result.Models[1].Score).To(BeNil())
 		})
 
@@ -227,7 +230,7 @@ var _ = Describe("Detection Functions", func() {
 			result := realitydefender.FormatResult(&mediaResponse)
 
 			// Verify the result
-			Expect(result.Status).To(Equal("ERROR"))
+			Expect(result.Status).To(Equal(realitydefender.StatusError))
 			Expect(result.Score).To(BeNil())
 			Expect(len(result.Models)).To(Equal(0))
 		})
@@ -292,7 +295,7 @@ var _ = Describe("Detection Functions", func() {
 			result, err := client.GetResult(ctx, "test-request-id", options)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).NotTo(BeNil())
-			Expect(result.Status).To(Equal("MANIPULATED"))
+			Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Score).To(Equal(0.95))
 
 			// Verify we made exactly 3 requests (2 processing + 1 completed)
@@ -343,9 +346,14 @@ var _ = Describe("Client File Upload Validation", func() {
 		validTxtFile string
 		largeTxtFile string
 		invalidFile  string
+		uploadedLen  int64
+		uploadedBody []byte
 	)
 
 	BeforeEach(func() {
+		uploadedLen = -1
+		uploadedBody = nil
+
 		// Create mock HTTP server
 		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.URL.Path {
@@ -361,6 +369,8 @@ var _ = Describe("Client File Upload Validation", func() {
 					"requestId": "test-request-id"
 				}`))
 			case "/upload":
+				uploadedLen = r.ContentLength
+				uploadedBody, _ = io.ReadAll(r.Body)
 				w.WriteHeader(http.StatusOK)
 			default:
 				w.WriteHeader(http.StatusNotFound)
@@ -437,6 +447,169 @@ var _ = Describe("Client File Upload Validation", func() {
 			Expect(result).NotTo(BeNil())
 			Expect(result.RequestID).To(Equal("test-request-id"))
 			Expect(result.MediaID).To(Equal("test-media-id"))
+			Expect(uploadedLen).To(Equal(int64(len("test content"))))
+			Expect(string(uploadedBody)).To(Equal("test content"))
+		})
+	})
+
+	Context("when uploading from a reader", func() {
+		It("should stream the content with a known length", func() {
+			content := "streamed content"
+			result, err := client.UploadReader(context.Background(), "clip.txt", strings.NewReader(content), int64(len(content)))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequestID).To(Equal("test-request-id"))
+			Expect(uploadedLen).To(Equal(int64(len(content))))
+			Expect(string(uploadedBody)).To(Equal(content))
+		})
+
+		It("should only send size bytes from a longer reader", func() {
+			result, err := client.UploadReader(context.Background(), "clip.txt", strings.NewReader("0123456789"), 4)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).NotTo(BeNil())
+			Expect(string(uploadedBody)).To(Equal("0123"))
+		})
+
+		It("should reject unsupported names", func() {
+			_, err := client.UploadReader(context.Background(), "clip.xyz", strings.NewReader("data"), 4)
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidFile))
+		})
+
+		It("should reject sizes over the limit", func() {
+			_, err := client.UploadReader(context.Background(), "clip.txt", strings.NewReader(""), 5242881)
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeFileTooLarge))
+		})
+
+		It("should fail when the reader is shorter than size", func() {
+			_, err := client.UploadReader(context.Background(), "clip.txt", strings.NewReader("abc"), 10)
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeUploadFailed))
+		})
+
+		It("should reject a nil reader", func() {
+			_, err := client.UploadReader(context.Background(), "clip.txt", nil, 0)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("reader is required"))
+		})
+	})
+
+	Context("when uploading from other sources", func() {
+		It("should upload in-memory data", func() {
+			result, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "memo.txt",
+				Data:     []byte("in memory"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequestID).To(Equal("test-request-id"))
+			Expect(string(uploadedBody)).To(Equal("in memory"))
+		})
+
+		It("should upload a reader with an explicit size", func() {
+			result, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "stream.txt",
+				Reader:   io.MultiReader(strings.NewReader("a"), strings.NewReader("bc")),
+				Size:     3,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).NotTo(BeNil())
+			Expect(uploadedLen).To(Equal(int64(3)))
+			Expect(string(uploadedBody)).To(Equal("abc"))
+		})
+
+		It("should discover the size of readers that report it", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "stream.txt",
+				Reader:   strings.NewReader("sized"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(uploadedBody)).To(Equal("sized"))
+		})
+
+		It("should require a size for readers of unknown length", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "stream.txt",
+				Reader:   io.MultiReader(strings.NewReader("abc")),
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidFile))
+		})
+
+		It("should upload a file from an fs.FS", func() {
+			fsys := fstest.MapFS{
+				"media/photo.txt": &fstest.MapFile{Data: []byte("from fs")},
+			}
+
+			result, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FS:       fsys,
+				FilePath: "media/photo.txt",
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).NotTo(BeNil())
+			Expect(string(uploadedBody)).To(Equal("from fs"))
+		})
+
+		It("should report missing fs.FS files", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FS:       fstest.MapFS{},
+				FilePath: "missing.jpg",
+			})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("file not found"))
+		})
+
+		It("should apply the same validation to every source", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "memo.xyz",
+				Data:     []byte("data"),
+			})
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidFile))
+
+			_, err = client.Upload(context.Background(), realitydefender.UploadOptions{
+				FS:       fstest.MapFS{"big.txt": &fstest.MapFile{Data: make([]byte, 5242881)}},
+				FilePath: "big.txt",
+			})
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeFileTooLarge))
+		})
+
+		It("should reject more than one source", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FilePath: validTxtFile,
+				FileName: "memo.txt",
+				Data:     []byte("data"),
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidRequest))
+		})
+
+		It("should require a file name for in-memory data", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				Data: []byte("data"),
+			})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("file name is required"))
 		})
 	})
 })
@@ -0,0 +1,188 @@
+package realitydefender
+
+import (
+	"sync"
+	"time"
+)
+
+// Event names an event emitted by the client
+type Event string
+
+// Events emitted by the client, with the type of their data
+const (
+	EventResult      Event = "result"       // *DetectionResult, from PollForResults
+	EventError       Event = "error"        // error, from PollForResults
+	EventProgress    Event = "progress"     // UploadProgress, while media is uploaded
+	EventPollAttempt Event = "poll_attempt" // PollAttempt, after each result poll
+)
+
+// EventHandler is a function that handles SDK events
+type EventHandler func(interface{})
+
+// Unsubscribe removes an event handler. Calling it more than once has no effect.
+type Unsubscribe func()
+
+// PollAttempt describes one poll for a detection result
+type PollAttempt struct {
+	// RequestID is the request being polled
+	RequestID string
+	// Attempt is the 1-based attempt number
+	Attempt int
+	// Status is the status returned by the poll, empty if it failed
+	Status Status
+	// Err is the error of the poll, such as a not-found error while the result is not ready
+	Err error
+	// Duration is how long the poll took
+	Duration time.Duration
+}
+
+// subscription is a registered event handler
+type subscription struct {
+	id      uint64
+	handler EventHandler
+}
+
+// eventBus holds event handlers and delivers events to them
+type eventBus struct {
+	mu       sync.RWMutex
+	handlers map[Event][]subscription
+	nextID   uint64
+
+	// async delivery state: queued deliveries and whether a goroutine is draining them
+	async       bool
+	queueMu     sync.Mutex
+	queue       []func()
+	dispatching bool
+}
+
+// newEventBus creates an event bus, delivering events on a separate goroutine when async is set
+func newEventBus(async bool) *eventBus {
+	return &eventBus{
+		handlers: make(map[Event][]subscription),
+		async:    async,
+	}
+}
+
+// subscribe registers a handler and returns the function removing it
+func (b *eventBus) subscribe(event Event, handler EventHandler) Unsubscribe {
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	b.handlers[event] = append(b.handlers[event], subscription{id: id, handler: handler})
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			subscriptions := b.handlers[event]
+			for i, sub := range subscriptions {
+				if sub.id == id {
+					// Copy so that an emit iterating over the old slice is unaffected
+					b.handlers[event] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// emit delivers data to the handlers of an event. Handlers run without the lock held, so they
+// may subscribe and unsubscribe; with async delivery they run in order on another goroutine.
+func (b *eventBus) emit(event Event, data interface{}) {
+	b.mu.RLock()
+	subscriptions := b.handlers[event]
+	b.mu.RUnlock()
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	deliver := func() {
+		for _, sub := range subscriptions {
+			sub.handler(data)
+		}
+	}
+
+	if !b.async {
+		deliver()
+		return
+	}
+
+	b.queueMu.Lock()
+	b.queue = append(b.queue, deliver)
+	if b.dispatching {
+		b.queueMu.Unlock()
+		return
+	}
+	b.dispatching = true
+	b.queueMu.Unlock()
+
+	go b.drain()
+}
+
+// drain runs queued deliveries until the queue is empty
+func (b *eventBus) drain() {
+	for {
+		b.queueMu.Lock()
+		if len(b.queue) == 0 {
+			b.dispatching = false
+			b.queueMu.Unlock()
+			return
+		}
+		deliver := b.queue[0]
+		b.queue[0] = nil
+		b.queue = b.queue[1:]
+		b.queueMu.Unlock()
+
+		deliver()
+	}
+}
+
+// On registers a handler for an event by name. The handler receives the event's data as
+// interface{}; see the Event constants for its type.
+//
+// Deprecated: Use OnResult, OnError, OnProgress or OnPollAttempt, which are type-safe.
+func (c *Client) On(event string, handler EventHandler) Unsubscribe {
+	return c.events.subscribe(Event(event), handler)
+}
+
+// OnResult registers a handler for final results found by PollForResults
+func (c *Client) OnResult(handler func(*DetectionResult)) Unsubscribe {
+	return c.events.subscribe(EventResult, func(data interface{}) {
+		handler(data.(*DetectionResult))
+	})
+}
+
+// OnError registers a handler for errors that stop PollForResults
+func (c *Client) OnError(handler func(error)) Unsubscribe {
+	return c.events.subscribe(EventError, func(data interface{}) {
+		handler(data.(error))
+	})
+}
+
+// OnProgress registers a handler for the progress of every upload made by the client
+func (c *Client) OnProgress(handler func(UploadProgress)) Unsubscribe {
+	return c.events.subscribe(EventProgress, func(data interface{}) {
+		handler(data.(UploadProgress))
+	})
+}
+
+// OnPollAttempt registers a handler called after each poll for a detection result
+func (c *Client) OnPollAttempt(handler func(PollAttempt)) Unsubscribe {
+	return c.events.subscribe(EventPollAttempt, func(data interface{}) {
+		handler(data.(PollAttempt))
+	})
+}
+
+// emit delivers an event to its handlers
+func (c *Client) emit(event Event, data interface{}) {
+	c.events.emit(event, data)
+}
+
+// emitPollAttempt reports a poll to OnPollAttempt handlers
+func (c *Client) emitPollAttempt(attempt PollAttempt) {
+	c.emit(EventPollAttempt, attempt)
+}
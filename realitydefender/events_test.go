@@ -0,0 +1,149 @@
+package realitydefender_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		server *realitydefendertest.Server
+	)
+
+	BeforeEach(func() {
+		server = newFakeServer()
+		// The first poll finds nothing, the second the final result
+		server.AddResult("req-1", realitydefendertest.Outcome{NotFoundPolls: 1, Status: "AUTHENTIC"})
+	})
+
+	newClient := func(async bool) *realitydefender.Client {
+		client, err := server.NewClient(realitydefender.Config{AsyncEvents: async})
+		Expect(err).NotTo(HaveOccurred())
+		return client
+	}
+
+	It("delivers typed results to OnResult handlers", func() {
+		client := newClient(false)
+		// PollForResults waits seconds after a not-found poll, so start at the final result
+		server.AddResult("req-1", realitydefendertest.Outcome{Status: "AUTHENTIC"})
+		var results []*realitydefender.DetectionResult
+		client.OnResult(func(result *realitydefender.DetectionResult) {
+			results = append(results, result)
+		})
+
+		Expect(client.PollForResults(context.Background(), "req-1", &realitydefender.PollOptions{PollingInterval: 1, Timeout: 1000})).To(Succeed())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Status).To(Equal(realitydefender.StatusAuthentic))
+	})
+
+	It("reports each poll to OnPollAttempt handlers", func() {
+		client := newClient(false)
+		var attempts []realitydefender.PollAttempt
+		client.OnPollAttempt(func(attempt realitydefender.PollAttempt) {
+			attempts = append(attempts, attempt)
+		})
+
+		_, err := client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{PollingInterval: 1})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(attempts).To(HaveLen(2))
+		Expect(attempts[0].RequestID).To(Equal("req-1"))
+		Expect(attempts[0].Attempt).To(Equal(1))
+		Expect(attempts[0].Err).To(MatchError(realitydefender.ErrNotFound))
+		Expect(attempts[0].Status).To(BeEmpty())
+		Expect(attempts[1].Attempt).To(Equal(2))
+		Expect(attempts[1].Err).NotTo(HaveOccurred())
+		Expect(attempts[1].Status).To(Equal(realitydefender.StatusAuthentic))
+	})
+
+	It("stops calling a handler once unsubscribed", func() {
+		client := newClient(false)
+		calls := 0
+		unsubscribe := client.OnPollAttempt(func(realitydefender.PollAttempt) {
+			calls++
+		})
+
+		_, err := client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{PollingInterval: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+
+		unsubscribe()
+		unsubscribe()
+		_, err = client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{PollingInterval: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+	})
+
+	It("lets handlers subscribe and unsubscribe while being called", func() {
+		client := newClient(false)
+		var (
+			unsubscribe realitydefender.Unsubscribe
+			first       int
+			added       int
+		)
+		unsubscribe = client.OnPollAttempt(func(realitydefender.PollAttempt) {
+			first++
+			unsubscribe()
+			client.OnPollAttempt(func(realitydefender.PollAttempt) {
+				added++
+			})
+		})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err := client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{PollingInterval: 1})
+			Expect(err).NotTo(HaveOccurred())
+		}()
+		Eventually(done).Should(BeClosed())
+
+		Expect(first).To(Equal(1))
+		Expect(added).To(Equal(1))
+	})
+
+	It("delivers events in order without blocking when async", func() {
+		client := newClient(true)
+		var (
+			mu       sync.Mutex
+			attempts []int
+		)
+		release := make(chan struct{})
+		client.OnPollAttempt(func(attempt realitydefender.PollAttempt) {
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			attempts = append(attempts, attempt.Attempt)
+		})
+
+		// The blocked handler does not hold up the poll
+		_, err := client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{PollingInterval: 1})
+		Expect(err).NotTo(HaveOccurred())
+
+		close(release)
+		Eventually(func() []int {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]int(nil), attempts...)
+		}, time.Second).Should(Equal([]int{1, 2}))
+	})
+
+	It("keeps supporting handlers registered by name", func() {
+		client := newClient(false)
+		// PollForResults waits seconds after a not-found poll, so start at the final result
+		server.AddResult("req-1", realitydefendertest.Outcome{Status: "AUTHENTIC"})
+		var status realitydefender.Status
+		unsubscribe := client.On("result", func(data interface{}) {
+			status = data.(*realitydefender.DetectionResult).Status
+		})
+		defer unsubscribe()
+
+		Expect(client.PollForResults(context.Background(), "req-1", &realitydefender.PollOptions{PollingInterval: 1, Timeout: 1000})).To(Succeed())
+		Expect(status).To(Equal(realitydefender.StatusAuthentic))
+	})
+})
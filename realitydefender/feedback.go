@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const userFeedbackEndpoint = "/api/v2/user-feedback"
@@ -15,7 +17,10 @@ type userFeedbackPayload struct {
 	Comment          *string `json:"comment,omitempty"`
 }
 
-func createUserFeedback(ctx context.Context, client *httpClient, opts CreateUserFeedbackOptions) (*UserFeedback, error) {
+func createUserFeedback(ctx context.Context, client *httpClient, opts CreateUserFeedbackOptions) (feedback *UserFeedback, err error) {
+	ctx, span := client.telemetry.startSpan(ctx, "CreateUserFeedback", attribute.String("realitydefender.request_id", opts.RequestID))
+	defer func() { endSpan(span, err) }()
+
 	if opts.RequestID == "" || opts.Label == "" || opts.FeedbackCategory == "" {
 		return nil, &SDKError{
 			Message: "requestId, label, and feedbackCategory are required",
@@ -32,10 +37,7 @@ func createUserFeedback(ctx context.Context, client *httpClient, opts CreateUser
 
 	responseData, err := client.post(ctx, userFeedbackEndpoint, payload)
 	if err != nil {
-		return nil, &SDKError{
-			Message: fmt.Sprintf("user feedback submission failed: %v", err),
-			Code:    ErrorCodeUploadFailed,
-		}
+		return nil, wrapError(err, "user feedback submission failed", ErrorCodeUploadFailed)
 	}
 
 	var out UserFeedback
@@ -43,6 +45,7 @@ func createUserFeedback(ctx context.Context, client *httpClient, opts CreateUser
 		return nil, &SDKError{
 			Message: fmt.Sprintf("invalid response from user feedback API: %v", err),
 			Code:    ErrorCodeServerError,
+			Err:     err,
 		}
 	}
 
@@ -3,6 +3,7 @@ package realitydefender_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -121,4 +122,38 @@ var _ = Describe("CreateUserFeedback", func() {
 			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidRequest))
 		})
 	})
+
+	Context("when the API rejects the request", func() {
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"code":"invalid-api-key","response":"Invalid API key","errno":1001,"requestId":"req-1"}`))
+			}))
+			var err error
+			client, err = realitydefender.New(realitydefender.Config{
+				APIKey:  "test-api-key",
+				BaseURL: server.URL,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps the original code and response details", func() {
+			_, err := client.CreateUserFeedback(context.Background(), realitydefender.CreateUserFeedbackOptions{
+				RequestID:        "req-1",
+				Label:            "REAL",
+				FeedbackCategory: "CONFIRMATION",
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("user feedback submission failed:"))
+			Expect(errors.Is(err, realitydefender.ErrUnauthorized)).To(BeTrue())
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(sdkErr.ServerCode).To(Equal("invalid-api-key"))
+			Expect(sdkErr.ErrNo).To(Equal(1001))
+			Expect(sdkErr.RequestID).To(Equal("req-1"))
+			Expect(sdkErr.Retryable()).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,90 @@
+package realitydefender
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces sensitive values in logs and error messages
+const redacted = "REDACTED"
+
+// sensitiveHeaders lists the canonical names of headers whose values are never logged
+var sensitiveHeaders = map[string]bool{
+	"X-Api-Key":     true,
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// discardHandler is a slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// newLogger returns the configured logger, or one that discards everything
+func newLogger(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(discardHandler{})
+	}
+	return logger
+}
+
+// redactURL strips the query string from presigned URLs, whose signature grants access to the upload.
+// Other URLs are returned unchanged.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" || !isSignedQuery(parsed.Query()) {
+		return rawURL
+	}
+
+	parsed.RawQuery = redacted
+	return parsed.String()
+}
+
+// isSignedQuery reports whether query parameters carry a URL signature or credentials
+func isSignedQuery(query url.Values) bool {
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.Contains(lower, "signature") ||
+			strings.Contains(lower, "credential") ||
+			strings.Contains(lower, "security-token") ||
+			lower == "sig" {
+			return true
+		}
+	}
+	return false
+}
+
+// redactError removes presigned URL signatures that net/http embeds in *url.Error messages
+func redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		redactedErr := *urlErr
+		redactedErr.URL = redactURL(urlErr.URL)
+		return &redactedErr
+	}
+	return err
+}
+
+// redactedHeader logs HTTP headers with sensitive values replaced
+type redactedHeader http.Header
+
+// LogValue implements slog.LogValuer
+func (h redactedHeader) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,144 @@
+package realitydefender_test
+
+import (
+	"bytes"
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent log writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var _ = Describe("Logging", func() {
+	const (
+		apiKey    = "super-secret-api-key"
+		signature = "fake-signature"
+	)
+
+	var (
+		server *realitydefendertest.Server
+		logs   *syncBuffer
+		client *realitydefender.Client
+	)
+
+	BeforeEach(func() {
+		logs = &syncBuffer{}
+		server = newFakeServer()
+		server.FailNext(realitydefendertest.EndpointUpload, http.StatusServiceUnavailable, 1)
+
+		var err error
+		client, err = server.NewClient(realitydefender.Config{
+			APIKey:      apiKey,
+			Logger:      slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+			RetryPolicy: &realitydefender.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("logs requests, retries and uploads", func() {
+		_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		output := logs.String()
+		Expect(output).To(ContainSubstring("uploading media"))
+		Expect(output).To(ContainSubstring("size=7"))
+		Expect(output).To(ContainSubstring("request started"))
+		Expect(output).To(ContainSubstring("request completed"))
+		Expect(output).To(ContainSubstring("retrying request"))
+		Expect(output).To(ContainSubstring("endpoint=upload"))
+	})
+
+	It("redacts the API key and presigned URL signatures", func() {
+		_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		output := logs.String()
+		Expect(output).To(ContainSubstring("X-Api-Key=REDACTED"))
+		Expect(output).NotTo(ContainSubstring(apiKey))
+		Expect(output).NotTo(ContainSubstring(signature))
+	})
+
+	It("logs polling iterations and error mapping", func() {
+		_, err := client.GetResult(context.Background(), "missing-request", &realitydefender.GetResultOptions{
+			MaxAttempts:     2,
+			PollingInterval: 10,
+		})
+		Expect(err).To(HaveOccurred())
+
+		output := logs.String()
+		Expect(output).To(ContainSubstring("API error response"))
+		Expect(output).To(ContainSubstring("code=not_found"))
+		Expect(output).To(ContainSubstring("result not found yet"))
+		Expect(output).To(ContainSubstring("request_id=missing-request"))
+	})
+
+	It("keeps presigned URL signatures out of error messages", func() {
+		closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		closedURL := closed.URL
+		closed.Close()
+
+		mux := http.NewServeMux()
+		signing := httptest.NewServer(mux)
+		defer signing.Close()
+		mux.HandleFunc("/api/files/aws-presigned", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"response":{"signedUrl":"` + closedURL + `/upload?X-Amz-Signature=` + signature + `"},"requestId":"test-request-id"}`))
+		})
+
+		failing, err := realitydefender.New(realitydefender.Config{
+			APIKey:  apiKey,
+			BaseURL: signing.URL,
+			Logger:  slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = failing.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).NotTo(ContainSubstring(signature))
+		Expect(err.Error()).To(ContainSubstring("REDACTED"))
+		Expect(logs.String()).To(ContainSubstring("request failed"))
+		Expect(logs.String()).NotTo(ContainSubstring(signature))
+	})
+
+	It("stays silent without a logger", func() {
+		quiet, err := server.NewClient(realitydefender.Config{APIKey: apiKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = quiet.GetResult(context.Background(), "missing-request", &realitydefender.GetResultOptions{MaxAttempts: 1})
+		Expect(err).To(HaveOccurred())
+		Expect(logs.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,233 @@
+package realitydefender
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// ManifestEntry is the recorded progress of one batch input, identified by its content hash
+type ManifestEntry struct {
+	// Hash is the hex SHA-256 of the input content
+	Hash string `json:"hash"`
+	// Source is the input's file path or name
+	Source string `json:"source,omitempty"`
+	// RequestID is the request ID of the upload, empty until the upload succeeds
+	RequestID string `json:"requestId,omitempty"`
+	// UploadedAt is when the upload finished
+	UploadedAt *time.Time `json:"uploadedAt,omitempty"`
+	// Result is the final detection result, nil while the detection is in flight
+	Result *DetectionResult `json:"result,omitempty"`
+}
+
+// Manifest records batch progress in a JSON-lines file so an interrupted batch can be resumed.
+// Each line holds the latest state of one entry; later lines for the same hash replace earlier ones.
+// A Manifest is safe for concurrent use.
+type Manifest struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]ManifestEntry
+	uploads keyLocks
+}
+
+// OpenManifest loads the manifest at path, creating it if needed, and opens it for appending
+func OpenManifest(path string) (*Manifest, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to open manifest: %v", err),
+			Code:    ErrorCodeInvalidRequest,
+			Err:     err,
+		}
+	}
+
+	manifest := &Manifest{file: file, entries: map[string]ManifestEntry{}}
+	if err := manifest.load(); err != nil {
+		file.Close()
+		return nil, &SDKError{
+			Message: fmt.Sprintf("failed to read manifest: %v", err),
+			Code:    ErrorCodeInvalidRequest,
+			Err:     err,
+		}
+	}
+	return manifest, nil
+}
+
+// load reads the existing entries. A line cut short by a crash is ignored, and a newline is
+// appended after it so the next entry starts on a line of its own.
+func (m *Manifest) load() error {
+	data, err := io.ReadAll(m.file)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		var entry ManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Hash == "" {
+			continue
+		}
+		m.entries[entry.Hash] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		_, err = m.file.Write([]byte{'\n'})
+	}
+	return err
+}
+
+// Entry returns the recorded state for a content hash
+func (m *Manifest) Entry(hash string) (ManifestEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[hash]
+	return entry, ok
+}
+
+// Close closes the manifest file
+func (m *Manifest) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.file.Close()
+}
+
+// record merges the update into the entry for its hash and appends the result as a new line
+func (m *Manifest) record(update ManifestEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.entries[update.Hash]
+	entry.Hash = update.Hash
+	if update.Source != "" {
+		entry.Source = update.Source
+	}
+	if update.RequestID != "" {
+		entry.RequestID = update.RequestID
+	}
+	if update.UploadedAt != nil {
+		entry.UploadedAt = update.UploadedAt
+	}
+	if update.Result != nil {
+		entry.Result = update.Result
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := m.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	m.entries[entry.Hash] = entry
+	return nil
+}
+
+// Resume continues a batch recorded in the manifest at path, creating the manifest if it does
+// not exist. Inputs whose content already has a final result are returned from the manifest,
+// inputs that were uploaded but not finished are only polled, and the rest are uploaded.
+// The manifest is closed when the batch finishes, which needs its results to be received or
+// Wait to be called, or when ctx is done.
+func (c *Client) Resume(ctx context.Context, manifestPath string, inputs []UploadOptions, options BatchOptions) (*Batch, error) {
+	manifest, err := OpenManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Manifest = manifest
+	batch := c.DetectBatch(ctx, inputs, options)
+	go func() {
+		select {
+		case <-batch.done:
+		case <-ctx.Done():
+		}
+		manifest.Close()
+	}()
+	return batch, nil
+}
+
+// contentHash returns the hex SHA-256 of an upload's content. Readers must be seekable,
+// since the content is read again for the upload.
+func contentHash(options UploadOptions) (string, error) {
+	hash := sha256.New()
+
+	switch {
+	case options.Data != nil:
+		hash.Write(options.Data)
+	case options.Reader != nil:
+		seeker, ok := options.Reader.(io.Seeker)
+		if !ok {
+			return "", &SDKError{
+				Message: "content hashing requires a reader that implements io.Seeker",
+				Code:    ErrorCodeInvalidRequest,
+			}
+		}
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err == nil {
+			r := options.Reader
+			if options.Size > 0 {
+				r = io.LimitReader(r, options.Size)
+			}
+			_, err = io.Copy(hash, r)
+		}
+		if err == nil {
+			_, err = seeker.Seek(start, io.SeekStart)
+		}
+		if err != nil {
+			return "", &SDKError{
+				Message: fmt.Sprintf("failed to read file: %v", err),
+				Code:    ErrorCodeInvalidFile,
+				Err:     err,
+			}
+		}
+	default:
+		var file io.ReadCloser
+		var err error
+		if options.FS != nil {
+			file, err = options.FS.Open(options.FilePath)
+		} else {
+			file, err = os.Open(options.FilePath)
+		}
+		if err == nil {
+			_, err = io.Copy(hash, file)
+			file.Close()
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", &SDKError{
+				Message: fmt.Sprintf("file not found: %s", options.FilePath),
+				Code:    ErrorCodeInvalidFile,
+				Err:     err,
+			}
+		}
+		if err != nil {
+			return "", &SDKError{
+				Message: fmt.Sprintf("failed to read file: %v", err),
+				Code:    ErrorCodeInvalidFile,
+				Err:     err,
+			}
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// inputSource describes an upload input for the manifest
+func inputSource(options UploadOptions) string {
+	if options.FilePath != "" {
+		return options.FilePath
+	}
+	return options.FileName
+}
@@ -0,0 +1,200 @@
+package realitydefender_test
+
+import (
+	"context"
+	"encoding/json"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest", func() {
+	var (
+		server *realitydefendertest.Server
+		client *realitydefender.Client
+		path   string
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "job.jsonl")
+
+		server = newFakeServer()
+		server.SetDefaultOutcome(realitydefendertest.Outcome{Status: "AUTHENTIC"})
+		server.SetOutcome("b.txt", realitydefendertest.Outcome{AnalyzingPolls: 1, Status: "FAKE"})
+
+		var err error
+		client, err = server.NewClient(realitydefender.Config{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	input := func(name string) realitydefender.UploadOptions {
+		return realitydefender.UploadOptions{FileName: name, Data: []byte("content of " + name)}
+	}
+
+	uploads := func() map[string]int {
+		counts := map[string]int{}
+		for _, request := range server.RequestsTo(realitydefendertest.EndpointSignedURL) {
+			var payload map[string]string
+			Expect(json.Unmarshal(request.Body, &payload)).To(Succeed())
+			counts[payload["fileName"]]++
+		}
+		return counts
+	}
+	options := realitydefender.BatchOptions{ResultOptions: realitydefender.GetResultOptions{MaxAttempts: 1}}
+
+	It("skips completed items and only re-polls in-flight ones", func() {
+		batch, err := client.Resume(context.Background(), path, []realitydefender.UploadOptions{input("a.txt"), input("b.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+		summary := batch.Wait()
+		Expect(summary.StatusCounts).To(Equal(map[realitydefender.Status]int{realitydefender.StatusAuthentic: 1}))
+		Expect(summary.Pending).To(Equal(1))
+
+		batch, err = client.Resume(context.Background(), path, []realitydefender.UploadOptions{input("a.txt"), input("b.txt"), input("c.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+
+		results := map[int]realitydefender.BatchItemResult{}
+		for item := range batch.Results() {
+			results[item.Index] = item
+		}
+		summary = batch.Wait()
+
+		Expect(summary.Resumed).To(Equal(2))
+		Expect(results[0].Resumed).To(BeTrue())
+		Expect(results[0].Result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(results[1].Result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(results[2].Resumed).To(BeFalse())
+		Expect(uploads()).To(Equal(map[string]int{"a.txt": 1, "b.txt": 1, "c.txt": 1}))
+		Expect(server.Polls(results[0].RequestID)).To(Equal(1))
+		Expect(server.Polls(results[1].RequestID)).To(Equal(2))
+		Expect(server.Polls(results[2].RequestID)).To(Equal(1))
+	})
+
+	It("re-polls items whose models are still analyzing", func() {
+		server.SetOutcome("d.txt", realitydefendertest.Outcome{
+			Status: "AUTHENTIC",
+			Models: []realitydefendertest.Model{{Name: "rd-img", Status: "AUTHENTIC", AnalyzingPolls: 1}},
+		})
+
+		batch, err := client.Resume(context.Background(), path, []realitydefender.UploadOptions{input("d.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+		first := <-batch.Results()
+		batch.Wait()
+		Expect(first.Result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(first.Result.IsFinal()).To(BeFalse())
+
+		batch, err = client.Resume(context.Background(), path, []realitydefender.UploadOptions{input("d.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+		second := <-batch.Results()
+		batch.Wait()
+
+		Expect(second.Resumed).To(BeTrue())
+		Expect(second.Result.IsFinal()).To(BeTrue())
+		Expect(server.Polls(second.RequestID)).To(Equal(2))
+	})
+
+	It("uploads identical inputs once", func() {
+		var inputs []realitydefender.UploadOptions
+		for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+			inputs = append(inputs, realitydefender.UploadOptions{FileName: name, Data: []byte("same content")})
+		}
+		concurrent := options
+		concurrent.UploadConcurrency = 4
+
+		batch, err := client.Resume(context.Background(), path, inputs, concurrent)
+		Expect(err).NotTo(HaveOccurred())
+		summary := batch.Wait()
+
+		Expect(summary.Succeeded).To(Equal(4))
+		Expect(summary.Resumed).To(Equal(3))
+		Expect(server.RequestsTo(realitydefendertest.EndpointSignedURL)).To(HaveLen(1))
+	})
+
+	It("records hashes, request IDs, upload times and final results", func() {
+		batch, err := client.Resume(context.Background(), path, []realitydefender.UploadOptions{input("a.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+		item := <-batch.Results()
+		batch.Wait()
+
+		manifest, err := realitydefender.OpenManifest(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer manifest.Close()
+
+		entry, ok := manifest.Entry(item.Hash)
+		Expect(ok).To(BeTrue())
+		Expect(entry.Hash).To(HaveLen(64))
+		Expect(entry.Source).To(Equal("a.txt"))
+		Expect(entry.RequestID).To(Equal(item.RequestID))
+		Expect(entry.RequestID).NotTo(BeEmpty())
+		Expect(entry.UploadedAt).NotTo(BeNil())
+		Expect(entry.Result.Status).To(Equal(realitydefender.StatusAuthentic))
+	})
+
+	It("tolerates a line cut short by a crash", func() {
+		Expect(os.WriteFile(path, []byte(`{"hash":"h1","requestId":"a.txt"}`+"\n"+`{"hash":"h2","req`), 0o600)).To(Succeed())
+
+		manifest, err := realitydefender.OpenManifest(path)
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := manifest.Entry("h1")
+		Expect(ok).To(BeTrue())
+		_, ok = manifest.Entry("h2")
+		Expect(ok).To(BeFalse())
+		Expect(manifest.Close()).To(Succeed())
+
+		batch, err := client.Resume(context.Background(), path, []realitydefender.UploadOptions{input("c.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+		item := <-batch.Results()
+		Expect(batch.Wait().Succeeded).To(Equal(1))
+
+		manifest, err = realitydefender.OpenManifest(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer manifest.Close()
+		_, ok = manifest.Entry("h1")
+		Expect(ok).To(BeTrue())
+		entry, ok := manifest.Entry(item.Hash)
+		Expect(ok).To(BeTrue())
+		Expect(entry.Result).NotTo(BeNil())
+	})
+
+	It("closes the manifest when the context is done, even if results are not read", func() {
+		if _, err := os.Stat("/proc/self/fd"); err != nil {
+			Skip("needs /proc to list open files")
+		}
+		// isOpen reports whether the process holds the manifest file open
+		isOpen := func() bool {
+			fds, _ := os.ReadDir("/proc/self/fd")
+			for _, fd := range fds {
+				if target, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); err == nil && target == path {
+					return true
+				}
+			}
+			return false
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := client.Resume(ctx, path, []realitydefender.UploadOptions{input("a.txt"), input("b.txt")}, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(isOpen()).To(BeTrue())
+
+		cancel()
+		Eventually(isOpen).Should(BeFalse())
+	})
+
+	It("fails readers that cannot be read twice", func() {
+		batch, err := client.Resume(context.Background(), path, []realitydefender.UploadOptions{{
+			FileName: "stream.txt",
+			Reader:   io.MultiReader(strings.NewReader("content")),
+			Size:     7,
+		}}, options)
+		Expect(err).NotTo(HaveOccurred())
+
+		summary := batch.Wait()
+		Expect(summary.Failed).To(Equal(1))
+		Expect(summary.Failures[0].Err).To(MatchError(realitydefender.ErrInvalidRequest))
+	})
+})
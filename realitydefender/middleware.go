@@ -0,0 +1,90 @@
+package realitydefender
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Endpoint names reported in RequestInfo
+const (
+	EndpointSignedURL    = "signed_url"    // POST /api/files/aws-presigned
+	EndpointUpload       = "upload"        // PUT to the presigned URL
+	EndpointMediaResult  = "media_result"  // GET /api/media/users/{requestId}
+	EndpointMediaResults = "media_results" // GET /api/v2/media/users/pages/{page}
+	EndpointSocialMedia  = "social_media"  // POST /api/files/social
+	EndpointUserFeedback = "user_feedback" // POST /api/v2/user-feedback
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do implements Doer
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add behavior around every request the client sends,
+// such as headers, logging or URL rewriting. It runs once per attempt, so retried
+// requests pass through it again.
+type Middleware func(next Doer) Doer
+
+// RequestInfo describes the SDK call a request belongs to
+type RequestInfo struct {
+	// Endpoint is the logical endpoint name, one of the Endpoint constants
+	Endpoint string
+	// Attempt is the 1-based attempt number when retries are enabled
+	Attempt int
+}
+
+// requestInfoKey is the context key for RequestInfo
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the RequestInfo attached to a request context.
+// Middleware can read it with RequestInfoFromContext(req.Context()).
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// withRequestInfo attaches RequestInfo to a context
+func withRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// chainMiddleware wraps the Doer so that the first middleware is the outermost
+func chainMiddleware(doer Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			doer = middleware[i](doer)
+		}
+	}
+	return doer
+}
+
+// endpointPrefixes maps API paths to endpoint names, most specific first
+var endpointPrefixes = []struct {
+	prefix string
+	name   string
+}{
+	{allMediaResultsEndpoint, EndpointMediaResults},
+	{mediaResultEndpoint, EndpointMediaResult},
+	{signedURLEndpoint, EndpointSignedURL},
+	{socialMediaEndpoint, EndpointSocialMedia},
+	{userFeedbackEndpoint, EndpointUserFeedback},
+}
+
+// endpointName returns the endpoint name for an API path
+func endpointName(path string) string {
+	for _, endpoint := range endpointPrefixes {
+		if strings.HasPrefix(path, endpoint.prefix) {
+			return endpoint.name
+		}
+	}
+	return path
+}
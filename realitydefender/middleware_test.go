@@ -0,0 +1,125 @@
+package realitydefender_test
+
+import (
+	"context"
+	"errors"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Middleware", func() {
+	type call struct {
+		endpoint string
+		method   string
+		status   int
+		latency  time.Duration
+	}
+
+	var (
+		server *realitydefendertest.Server
+		mu     sync.Mutex
+		calls  []call
+	)
+
+	recorder := func(next realitydefender.Doer) realitydefender.Doer {
+		return realitydefender.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			info, ok := realitydefender.RequestInfoFromContext(req.Context())
+			Expect(ok).To(BeTrue())
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+
+			mu.Lock()
+			calls = append(calls, call{info.Endpoint, req.Method, status, time.Since(start)})
+			mu.Unlock()
+			return resp, err
+		})
+	}
+
+	BeforeEach(func() {
+		calls = nil
+		server = newFakeServer()
+		server.AddResult("test-request-id", realitydefendertest.Outcome{Status: "AUTHENTIC"})
+	})
+
+	It("sees endpoint, method, status and latency of every request", func() {
+		client, err := server.NewClient(realitydefender.Config{Middleware: []realitydefender.Middleware{recorder}})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(calls).To(HaveLen(2))
+		Expect(calls[0].endpoint).To(Equal(realitydefender.EndpointSignedURL))
+		Expect(calls[0].method).To(Equal(http.MethodPost))
+		Expect(calls[0].status).To(Equal(http.StatusOK))
+		Expect(calls[1].endpoint).To(Equal(realitydefender.EndpointUpload))
+		Expect(calls[1].method).To(Equal(http.MethodPut))
+		Expect(calls[1].latency).To(BeNumerically(">", 0))
+	})
+
+	It("applies middleware in order, first outermost, and can rewrite requests", func() {
+		var order []string
+		gateway := func(next realitydefender.Doer) realitydefender.Doer {
+			return realitydefender.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, "gateway")
+				req.URL.Host = strings.TrimPrefix(server.URL, "http://")
+				req.Host = req.URL.Host
+				return next.Do(req)
+			})
+		}
+		correlation := func(next realitydefender.Doer) realitydefender.Doer {
+			return realitydefender.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, "correlation")
+				req.Header.Set("X-Correlation-ID", "corr-1")
+				return next.Do(req)
+			})
+		}
+
+		// Requests reach the server only through the gateway
+		client, err := realitydefender.New(realitydefender.Config{
+			APIKey:     realitydefendertest.APIKey,
+			BaseURL:    "http://gateway.invalid",
+			Middleware: []realitydefender.Middleware{gateway, correlation, recorder},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := client.GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(order).To(Equal([]string{"gateway", "correlation"}))
+		Expect(calls).To(HaveLen(1))
+		Expect(calls[0].endpoint).To(Equal(realitydefender.EndpointMediaResult))
+		Expect(server.RequestsTo(realitydefendertest.EndpointMediaResult)[0].Header.Get("X-Correlation-ID")).To(Equal("corr-1"))
+	})
+
+	It("surfaces middleware errors as request failures", func() {
+		blocked := func(next realitydefender.Doer) realitydefender.Doer {
+			return realitydefender.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: errors.New("blocked by policy")}
+			})
+		}
+
+		client, err := server.NewClient(realitydefender.Config{Middleware: []realitydefender.Middleware{blocked}})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("blocked by policy"))
+	})
+})
@@ -0,0 +1,468 @@
+package realitydefender
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Defaults for PollerOptions
+const (
+	DefaultPollerRequestsPerSecond = 10.0
+	DefaultPollerConcurrency       = 8
+	DefaultPollerPageSize          = 100
+	DefaultPollerMaxPages          = 5
+)
+
+// PollerOptions configures a Poller
+type PollerOptions struct {
+	// Interval is the time between two polls of the same request, used when Strategy is nil
+	// (defaults to DefaultPollingInterval)
+	Interval time.Duration
+	// Strategy decides the time between two polls of the same request (defaults to a ConstantPoll of Interval)
+	Strategy PollStrategy
+	// Timeout is how long a request is polled before it completes with ErrTimeout (defaults to DefaultTimeout)
+	Timeout time.Duration
+	// RequestsPerSecond caps the API requests made by the poller (defaults to DefaultPollerRequestsPerSecond)
+	RequestsPerSecond float64
+	// Concurrency caps the API requests the poller has in flight (defaults to DefaultPollerConcurrency)
+	Concurrency int
+	// PageSize is the number of results read per page when listing (defaults to DefaultPollerPageSize)
+	PageSize int
+	// MaxPages is the most pages read in one listing (defaults to DefaultPollerMaxPages).
+	// A negative value disables listing, so every request is polled by ID.
+	MaxPages int
+}
+
+// PollCompletion is the outcome of a request registered with a Poller
+type PollCompletion struct {
+	// RequestID is the registered request
+	RequestID string
+	// Result is the final result, or the last one seen when Err is set
+	Result *DetectionResult
+	// Err is the error that ended polling, such as ErrTimeout
+	Err error
+}
+
+// Poller polls many requests on a shared schedule, under a global rate and concurrency
+// budget. When enough requests are due, it reads the most recent results page by page
+// instead of fetching each request by ID. It is safe for concurrent use.
+type Poller struct {
+	client  *Client
+	options PollerOptions
+	limiter RateLimiter
+
+	mu            sync.Mutex
+	registrations map[string]*pollRegistration
+	nextID        uint64
+	closed        bool
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// pollRegistration is the polling state of a request. Its schedule fields are only used by
+// the poller's goroutine; callbacks are guarded by Poller.mu.
+type pollRegistration struct {
+	requestID string
+	mediaType MediaType
+	callbacks map[uint64]func(PollCompletion)
+	began     time.Time
+	deadline  time.Time
+	next      time.Time
+	attempts  int
+	// unlisted is set once a listing has missed the request, so it is polled by ID
+	unlisted bool
+	last     *DetectionResult
+}
+
+// NewPoller creates a Poller for the client and starts its scheduling goroutine, which runs
+// until Close is called
+func (c *Client) NewPoller(options PollerOptions) *Poller {
+	if options.Strategy == nil {
+		options.Strategy = ConstantPoll{Interval: options.Interval}
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = time.Duration(DefaultTimeout) * time.Millisecond
+	}
+	if options.RequestsPerSecond <= 0 {
+		options.RequestsPerSecond = DefaultPollerRequestsPerSecond
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultPollerConcurrency
+	}
+	if options.PageSize <= 0 {
+		options.PageSize = DefaultPollerPageSize
+	}
+	if options.MaxPages == 0 {
+		options.MaxPages = DefaultPollerMaxPages
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Poller{
+		client:        c,
+		options:       options,
+		limiter:       NewRateLimiter(options.RequestsPerSecond, options.Concurrency),
+		registrations: map[string]*pollRegistration{},
+		wake:          make(chan struct{}, 1),
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	go p.run(ctx)
+	return p
+}
+
+// Register polls a request until its result is final, then calls callback once with the
+// outcome. Registering a request that is already being polled shares its polls. The returned
+// function removes the callback; a request with no callbacks left is no longer polled.
+func (p *Poller) Register(requestID string, callback func(PollCompletion)) Unsubscribe {
+	return p.RegisterMedia(requestID, MediaTypeUnknown, callback)
+}
+
+// RegisterMedia is Register for media of a known type, which the poller passes to its
+// Strategy. A request already being polled keeps the media type it was first registered with.
+func (p *Poller) RegisterMedia(requestID string, mediaType MediaType, callback func(PollCompletion)) Unsubscribe {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		callback(PollCompletion{RequestID: requestID, Err: errPollerClosed()})
+		return func() {}
+	}
+
+	registration := p.registrations[requestID]
+	if registration == nil {
+		now := time.Now()
+		registration = &pollRegistration{
+			requestID: requestID,
+			mediaType: mediaType,
+			callbacks: map[uint64]func(PollCompletion){},
+			began:     now,
+			deadline:  now.Add(p.options.Timeout),
+			next:      now,
+		}
+		p.registrations[requestID] = registration
+	}
+	p.nextID++
+	id := p.nextID
+	registration.callbacks[id] = callback
+	p.mu.Unlock()
+
+	// Wake the scheduler so the request is polled right away
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			delete(registration.callbacks, id)
+			if len(registration.callbacks) == 0 && p.registrations[requestID] == registration {
+				delete(p.registrations, requestID)
+			}
+		})
+	}
+}
+
+// RegisterChan is Register delivering the outcome on a channel, which is closed after it
+func (p *Poller) RegisterChan(requestID string) <-chan PollCompletion {
+	return p.RegisterMediaChan(requestID, MediaTypeUnknown)
+}
+
+// RegisterMediaChan is RegisterMedia delivering the outcome on a channel, which is closed after it
+func (p *Poller) RegisterMediaChan(requestID string, mediaType MediaType) <-chan PollCompletion {
+	completions := make(chan PollCompletion, 1)
+	p.RegisterMedia(requestID, mediaType, func(completion PollCompletion) {
+		completions <- completion
+		close(completions)
+	})
+	return completions
+}
+
+// Pending returns the number of requests being polled
+func (p *Poller) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.registrations)
+}
+
+// Close stops polling. Requests still pending complete with an error.
+func (p *Poller) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.done
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.cancel()
+	<-p.done
+
+	p.mu.Lock()
+	registrations := p.registrations
+	p.registrations = map[string]*pollRegistration{}
+	p.mu.Unlock()
+
+	for _, registration := range registrations {
+		p.deliver(registration, PollCompletion{RequestID: registration.requestID, Result: registration.last, Err: errPollerClosed()})
+	}
+}
+
+// errPollerClosed is the error of requests pending when the poller is closed
+func errPollerClosed() error {
+	return &SDKError{
+		Message: "poller closed before the result was final",
+		Code:    ErrorCodeUnknownError,
+	}
+}
+
+// run is the scheduling loop: it polls the requests that are due, then sleeps until the
+// next one is due or a request is registered
+func (p *Poller) run(ctx context.Context) {
+	defer close(p.done)
+
+	for ctx.Err() == nil {
+		due, wait := p.due(ctx, time.Now())
+		if len(due) > 0 {
+			p.poll(ctx, due)
+			continue
+		}
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-p.wake:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// due returns the requests to poll now and, when there are none, how long until the next
+// one is due (zero when nothing is registered). Requests past their deadline are completed.
+func (p *Poller) due(ctx context.Context, now time.Time) (due []*pollRegistration, wait time.Duration) {
+	var expired []*pollRegistration
+
+	p.mu.Lock()
+	for _, registration := range p.registrations {
+		switch {
+		case !now.Before(registration.deadline):
+			expired = append(expired, registration)
+		case !now.Before(registration.next):
+			due = append(due, registration)
+		default:
+			until := registration.next.Sub(now)
+			if registration.deadline.Before(registration.next) {
+				until = registration.deadline.Sub(now)
+			}
+			if wait == 0 || until < wait {
+				wait = until
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	for _, registration := range expired {
+		p.client.httpClient.logger.WarnContext(ctx, "poller timed out", "request_id", registration.requestID, "timeout", p.options.Timeout)
+		p.complete(registration, PollCompletion{
+			RequestID: registration.requestID,
+			Result:    registration.last,
+			Err: &SDKError{
+				Message: "Polling timeout exceeded",
+				Code:    ErrorCodeTimeout,
+			},
+		})
+	}
+	return due, wait
+}
+
+// poll runs one round of polls for the due requests. Listing is used when reading at most
+// MaxPages pages is cheaper than fetching each listable request by ID; requests the listing
+// does not find are then fetched by ID.
+func (p *Poller) poll(ctx context.Context, due []*pollRegistration) {
+	remaining := due
+	if p.options.MaxPages > 0 {
+		listable := 0
+		for _, registration := range due {
+			if !registration.unlisted {
+				listable++
+			}
+		}
+		if listable > p.options.MaxPages {
+			remaining = p.pollPages(ctx, due)
+		}
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, p.options.Concurrency)
+	for _, registration := range remaining {
+		if ctx.Err() != nil {
+			break
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(registration *pollRegistration) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			p.pollOne(ctx, registration)
+		}(registration)
+	}
+	wg.Wait()
+}
+
+// pollPages reads result pages until every due request has been seen, returning the
+// requests the listing did not find
+func (p *Poller) pollPages(ctx context.Context, due []*pollRegistration) []*pollRegistration {
+	wanted := make(map[string]*pollRegistration, len(due))
+	for _, registration := range due {
+		wanted[registration.requestID] = registration
+	}
+
+	size := p.options.PageSize
+	exhausted := false
+	for page := 0; len(wanted) > 0; page++ {
+		if page >= p.options.MaxPages {
+			exhausted = true
+			break
+		}
+		if err := p.limiter.Wait(ctx); err != nil {
+			return nil
+		}
+
+		start := time.Now()
+		list, err := getDetectionResults(ctx, p.client.httpClient, &page, &size, nil, nil, nil, GetResultOptions{MaxAttempts: 1})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Fall back to fetching the remaining requests by ID
+			p.client.httpClient.logger.WarnContext(ctx, "poller listing failed", "page", page, "error", err)
+			break
+		}
+		duration := time.Since(start)
+
+		for i := range list.Items {
+			registration, ok := wanted[list.Items[i].RequestID]
+			if !ok {
+				continue
+			}
+			delete(wanted, registration.requestID)
+
+			result := list.Items[i]
+			registration.attempts++
+			registration.unlisted = false
+			p.client.emitPollAttempt(PollAttempt{
+				RequestID: registration.requestID,
+				Attempt:   registration.attempts,
+				Status:    result.Status,
+				Duration:  duration,
+			})
+			p.handle(ctx, registration, &result, nil)
+		}
+
+		if len(list.Items) < size || page >= list.TotalPages-1 {
+			break
+		}
+	}
+
+	var remaining []*pollRegistration
+	for _, registration := range due {
+		if _, ok := wanted[registration.requestID]; ok {
+			// A request older than the listed pages will not be found by listing again
+			if exhausted {
+				registration.unlisted = true
+			}
+			remaining = append(remaining, registration)
+		}
+	}
+	return remaining
+}
+
+// pollOne fetches the result of one request by ID
+func (p *Poller) pollOne(ctx context.Context, registration *pollRegistration) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return
+	}
+
+	registration.attempts++
+	result, err := p.client.pollOnce(ctx, registration.requestID, registration.attempts)
+	p.handle(ctx, registration, result, err)
+}
+
+// handle applies the outcome of a poll, completing the request or scheduling its next poll.
+// Missing results and retryable errors are polled again; other errors complete the request.
+func (p *Poller) handle(ctx context.Context, registration *pollRegistration, result *DetectionResult, err error) {
+	if ctx.Err() != nil {
+		// The poller is closing; Close completes the request
+		return
+	}
+
+	var sdkErr *SDKError
+	switch {
+	case err != nil && errors.As(err, &sdkErr) && sdkErr.Code == ErrorCodeNotFound:
+		p.schedule(registration)
+	case err != nil && errors.As(err, &sdkErr) && sdkErr.Retryable():
+		// A transient failure is polled again until the request's deadline
+		p.client.httpClient.logger.WarnContext(ctx, "poll failed, retrying", "request_id", registration.requestID, "error", err)
+		p.schedule(registration)
+	case err != nil:
+		p.complete(registration, PollCompletion{RequestID: registration.requestID, Result: registration.last, Err: err})
+	case !result.IsFinal():
+		registration.last = result
+		p.schedule(registration)
+	default:
+		p.client.handleResult(ctx, result)
+		p.complete(registration, PollCompletion{RequestID: registration.requestID, Result: result})
+	}
+}
+
+// schedule sets the time of the next poll of a request from the strategy
+func (p *Poller) schedule(registration *pollRegistration) {
+	now := time.Now()
+	registration.next = now.Add(p.options.Strategy.NextDelay(PollState{
+		RequestID: registration.requestID,
+		MediaType: registration.mediaType,
+		Attempt:   registration.attempts,
+		Elapsed:   now.Sub(registration.began),
+	}))
+}
+
+// complete removes a request and delivers its outcome, unless it was already removed
+func (p *Poller) complete(registration *pollRegistration, completion PollCompletion) {
+	p.mu.Lock()
+	if p.registrations[registration.requestID] != registration {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.registrations, registration.requestID)
+	p.mu.Unlock()
+
+	p.deliver(registration, completion)
+}
+
+// deliver calls the callbacks of a removed request
+func (p *Poller) deliver(registration *pollRegistration, completion PollCompletion) {
+	p.mu.Lock()
+	callbacks := make([]func(PollCompletion), 0, len(registration.callbacks))
+	for _, callback := range registration.callbacks {
+		callbacks = append(callbacks, callback)
+	}
+	p.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(completion)
+	}
+}
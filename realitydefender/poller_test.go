@@ -0,0 +1,312 @@
+package realitydefender_test
+
+import (
+	"fmt"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Poller", func() {
+	var (
+		server      *httptest.Server
+		client      *realitydefender.Client
+		mu          sync.Mutex
+		statuses    map[string]string
+		listed      []string
+		byID        int
+		pages       int
+		inFlight    int
+		maxInFlight int
+		delay       time.Duration
+		failures    int
+	)
+
+	BeforeEach(func() {
+		statuses = map[string]string{}
+		listed = nil
+		byID, pages, inFlight, maxInFlight = 0, 0, 0, 0
+		delay = 0
+		failures = 0
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			wait := delay
+			mu.Unlock()
+			time.Sleep(wait)
+
+			mu.Lock()
+			defer mu.Unlock()
+			inFlight--
+
+			media := func(id string) string {
+				return `{"requestId":"` + id + `","resultsSummary":{"status":"` + statuses[id] + `"},"models":[]}`
+			}
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/api/v2/media/users/pages/"):
+				pages++
+				var page, size int
+				fmt.Sscan(strings.TrimPrefix(r.URL.Path, "/api/v2/media/users/pages/"), &page)
+				fmt.Sscan(r.URL.Query().Get("size"), &size)
+
+				var items []string
+				for i := page * size; i < len(listed) && i < (page+1)*size; i++ {
+					items = append(items, media(listed[i]))
+				}
+				totalPages := (len(listed) + size - 1) / size
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"totalItems":%d,"totalPages":%d,"currentPage":%d,"currentPageItemsCount":%d,"mediaList":[%s]}`,
+					len(listed), totalPages, page, len(items), strings.Join(items, ","))
+			case strings.HasPrefix(r.URL.Path, "/api/media/users/"):
+				byID++
+				id := strings.TrimPrefix(r.URL.Path, "/api/media/users/")
+				if failures > 0 {
+					failures--
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if _, ok := statuses[id]; !ok {
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"code":"not-found","response":"not found"}`))
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(media(id)))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		var err error
+		client, err = realitydefender.New(realitydefender.Config{
+			APIKey:  "test-api-key",
+			BaseURL: server.URL,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	setStatus := func(id, status string) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[id] = status
+	}
+
+	fastOptions := realitydefender.PollerOptions{
+		Interval:          10 * time.Millisecond,
+		Timeout:           5 * time.Second,
+		RequestsPerSecond: 1000,
+	}
+
+	It("polls a few requests by ID and delivers to callbacks and channels", func() {
+		setStatus("a", "ANALYZING")
+		poller := client.NewPoller(fastOptions)
+		defer poller.Close()
+
+		var (
+			callbackMu sync.Mutex
+			completion realitydefender.PollCompletion
+		)
+		done := make(chan struct{})
+		poller.Register("a", func(c realitydefender.PollCompletion) {
+			callbackMu.Lock()
+			defer callbackMu.Unlock()
+			completion = c
+			close(done)
+		})
+		channel := poller.RegisterChan("b")
+
+		time.Sleep(30 * time.Millisecond)
+		setStatus("a", "FAKE")
+		setStatus("b", "AUTHENTIC")
+
+		Eventually(done).Should(BeClosed())
+		callbackMu.Lock()
+		Expect(completion.Err).NotTo(HaveOccurred())
+		Expect(completion.Result.Status).To(Equal(realitydefender.StatusManipulated))
+		callbackMu.Unlock()
+
+		var fromChannel realitydefender.PollCompletion
+		Eventually(channel).Should(Receive(&fromChannel))
+		Expect(fromChannel.RequestID).To(Equal("b"))
+		Expect(fromChannel.Result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Eventually(channel).Should(BeClosed())
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(pages).To(BeZero())
+		Expect(poller.Pending()).To(BeZero())
+	})
+
+	It("lists pages instead of fetching many requests by ID", func() {
+		options := fastOptions
+		options.PageSize = 10
+		options.MaxPages = 3
+		poller := client.NewPoller(options)
+		defer poller.Close()
+
+		// Slow responses let the registrations pile up while the first ones are polled
+		mu.Lock()
+		delay = 20 * time.Millisecond
+		mu.Unlock()
+
+		// 25 listed results, the 20 most recent of which are being polled
+		var channels []<-chan realitydefender.PollCompletion
+		for i := 0; i < 25; i++ {
+			id := fmt.Sprintf("req-%d", i)
+			setStatus(id, "AUTHENTIC")
+			mu.Lock()
+			listed = append(listed, id)
+			mu.Unlock()
+		}
+		for i := 0; i < 20; i++ {
+			channels = append(channels, poller.RegisterChan(fmt.Sprintf("req-%d", i)))
+		}
+
+		for i, channel := range channels {
+			var completion realitydefender.PollCompletion
+			Eventually(channel).Should(Receive(&completion))
+			Expect(completion.RequestID).To(Equal(fmt.Sprintf("req-%d", i)))
+			Expect(completion.Result.Status).To(Equal(realitydefender.StatusAuthentic))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		// Requests polled before the others were registered are fetched by ID, at most MaxPages
+		Expect(byID).To(BeNumerically("<=", 3))
+		Expect(pages).To(BeNumerically(">=", 1))
+		Expect(byID + pages).To(BeNumerically("<", 10))
+	})
+
+	It("falls back to fetching by ID for requests the listing does not find", func() {
+		options := fastOptions
+		options.PageSize = 2
+		options.MaxPages = 1
+		poller := client.NewPoller(options)
+		defer poller.Close()
+
+		// Only one page is read, which misses the older requests
+		mu.Lock()
+		listed = []string{"new-0", "new-1", "old-0", "old-1"}
+		mu.Unlock()
+		for _, id := range listed {
+			setStatus(id, "AUTHENTIC")
+		}
+
+		var channels []<-chan realitydefender.PollCompletion
+		for _, id := range []string{"new-0", "new-1", "old-0", "old-1"} {
+			channels = append(channels, poller.RegisterChan(id))
+		}
+		for _, channel := range channels {
+			var completion realitydefender.PollCompletion
+			Eventually(channel).Should(Receive(&completion))
+			Expect(completion.Err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("keeps requests in flight within the concurrency budget", func() {
+		options := fastOptions
+		options.Concurrency = 2
+		options.MaxPages = -1
+		poller := client.NewPoller(options)
+		defer poller.Close()
+
+		mu.Lock()
+		delay = 10 * time.Millisecond
+		mu.Unlock()
+
+		var channels []<-chan realitydefender.PollCompletion
+		for i := 0; i < 8; i++ {
+			id := fmt.Sprintf("req-%d", i)
+			setStatus(id, "AUTHENTIC")
+			channels = append(channels, poller.RegisterChan(id))
+		}
+		for _, channel := range channels {
+			Eventually(channel).Should(Receive())
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(pages).To(BeZero())
+		Expect(maxInFlight).To(BeNumerically("<=", 2))
+	})
+
+	It("times out requests that stay analyzing", func() {
+		setStatus("a", "ANALYZING")
+		options := fastOptions
+		options.Timeout = 50 * time.Millisecond
+		poller := client.NewPoller(options)
+		defer poller.Close()
+
+		var completion realitydefender.PollCompletion
+		Eventually(poller.RegisterChan("a")).Should(Receive(&completion))
+		Expect(completion.Err).To(MatchError(realitydefender.ErrTimeout))
+		Expect(completion.Result.Status).To(Equal(realitydefender.StatusAnalyzing))
+	})
+
+	It("polls again after a retryable error", func() {
+		setStatus("a", "AUTHENTIC")
+		mu.Lock()
+		failures = 2
+		mu.Unlock()
+		poller := client.NewPoller(fastOptions)
+		defer poller.Close()
+
+		var completion realitydefender.PollCompletion
+		Eventually(poller.RegisterChan("a")).Should(Receive(&completion))
+		Expect(completion.Err).NotTo(HaveOccurred())
+		Expect(completion.Result.Status).To(Equal(realitydefender.StatusAuthentic))
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(byID).To(Equal(3))
+	})
+
+	It("fails pending requests when closed and stops polling unsubscribed ones", func() {
+		poller := client.NewPoller(fastOptions)
+
+		unsubscribe := poller.Register("gone", func(realitydefender.PollCompletion) {
+			Fail("unsubscribed callback was called")
+		})
+		channel := poller.RegisterChan("pending")
+		unsubscribe()
+		Expect(poller.Pending()).To(Equal(1))
+
+		poller.Close()
+		var completion realitydefender.PollCompletion
+		Expect(channel).To(Receive(&completion))
+		Expect(completion.Err).To(HaveOccurred())
+
+		Expect(poller.RegisterChan("late")).To(Receive(&completion))
+		Expect(completion.Err).To(HaveOccurred())
+	})
+
+	It("reports its polls as poll attempts", func() {
+		setStatus("a", "AUTHENTIC")
+		attempts := make(chan realitydefender.PollAttempt, 10)
+		client.OnPollAttempt(func(attempt realitydefender.PollAttempt) {
+			attempts <- attempt
+		})
+		poller := client.NewPoller(fastOptions)
+		defer poller.Close()
+
+		Eventually(poller.RegisterChan("a")).Should(Receive())
+		var attempt realitydefender.PollAttempt
+		Expect(attempts).To(Receive(&attempt))
+		Expect(attempt.RequestID).To(Equal("a"))
+		Expect(attempt.Status).To(Equal(realitydefender.StatusAuthentic))
+	})
+})
@@ -0,0 +1,90 @@
+package realitydefender
+
+import (
+	"io"
+	"time"
+)
+
+// progressInterval is the minimum time between two progress reports of an upload
+const progressInterval = 100 * time.Millisecond
+
+// UploadProgress reports how far the upload of a file's content has got
+type UploadProgress struct {
+	// FileName is the name the media is uploaded under
+	FileName string
+	// BytesSent is the number of bytes streamed so far
+	BytesSent int64
+	// TotalBytes is the size of the content
+	TotalBytes int64
+	// Rate is the average upload rate in bytes per second
+	Rate float64
+	// ETA is the estimated time until the upload finishes, zero when done or unknown
+	ETA time.Duration
+}
+
+// Done reports whether all bytes have been sent
+func (p UploadProgress) Done() bool {
+	return p.BytesSent >= p.TotalBytes
+}
+
+// progressReader reports upload progress as the PUT body is read.
+// It is read by a single goroutine, the transport's, so it needs no locking.
+type progressReader struct {
+	reader   io.Reader
+	progress UploadProgress
+	report   func(UploadProgress)
+	start    time.Time
+	last     time.Time
+	finished bool
+}
+
+// newProgressReader wraps r to report progress for total bytes of fileName
+func newProgressReader(r io.Reader, fileName string, total int64, report func(UploadProgress)) *progressReader {
+	now := time.Now()
+	return &progressReader{
+		reader:   r,
+		progress: UploadProgress{FileName: fileName, TotalBytes: total},
+		report:   report,
+		start:    now,
+		last:     now,
+	}
+}
+
+// Read implements io.Reader, reporting at most every progressInterval and once on completion
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.progress.BytesSent += int64(n)
+
+	now := time.Now()
+	done := r.progress.Done()
+	if (n > 0 && now.Sub(r.last) >= progressInterval) || (done && !r.finished) {
+		r.last = now
+		r.finished = done
+
+		elapsed := now.Sub(r.start).Seconds()
+		r.progress.Rate = 0
+		r.progress.ETA = 0
+		if elapsed > 0 {
+			r.progress.Rate = float64(r.progress.BytesSent) / elapsed
+		}
+		if r.progress.Rate > 0 && !done {
+			remaining := float64(r.progress.TotalBytes - r.progress.BytesSent)
+			r.progress.ETA = time.Duration(remaining / r.progress.Rate * float64(time.Second))
+		}
+		r.report(r.progress)
+	}
+	return n, err
+}
+
+// withProgressEvents makes an upload report its progress to the client's OnProgress
+// handlers as well as to the per-call callback
+func (c *Client) withProgressEvents(options UploadOptions) UploadOptions {
+	callback := options.OnProgress
+	options.OnProgress = func(progress UploadProgress) {
+		if callback != nil {
+			callback(progress)
+		}
+		c.emit(EventProgress, progress)
+	}
+	return options
+}
@@ -0,0 +1,88 @@
+package realitydefender_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"io"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upload progress", func() {
+	var (
+		server *realitydefendertest.Server
+		client *realitydefender.Client
+	)
+
+	BeforeEach(func() {
+		server = newFakeServer()
+
+		var err error
+		client, err = server.NewClient(realitydefender.Config{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports progress to the per-call callback and to progress event handlers", func() {
+		var (
+			mu       sync.Mutex
+			callback []realitydefender.UploadProgress
+			events   []realitydefender.UploadProgress
+		)
+		client.OnProgress(func(progress realitydefender.UploadProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, progress)
+		})
+
+		data := make([]byte, 1<<20)
+		_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     data,
+			OnProgress: func(progress realitydefender.UploadProgress) {
+				mu.Lock()
+				defer mu.Unlock()
+				callback = append(callback, progress)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(callback).NotTo(BeEmpty())
+		Expect(events).To(Equal(callback))
+
+		last := callback[len(callback)-1]
+		Expect(last.Done()).To(BeTrue())
+		Expect(last.FileName).To(Equal("clip.txt"))
+		Expect(last.BytesSent).To(Equal(int64(len(data))))
+		Expect(last.TotalBytes).To(Equal(int64(len(data))))
+		Expect(last.Rate).To(BeNumerically(">", 0))
+		Expect(last.ETA).To(BeZero())
+
+		for i := 1; i < len(callback); i++ {
+			Expect(callback[i].BytesSent).To(BeNumerically(">=", callback[i-1].BytesSent))
+		}
+	})
+
+	It("reports progress for UploadReader through events", func() {
+		var done bool
+		client.OnProgress(func(progress realitydefender.UploadProgress) {
+			done = progress.Done()
+		})
+
+		_, err := client.UploadReader(context.Background(), "clip.txt", io.LimitReader(zeroReader{}, 1024), 1024)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(BeTrue())
+	})
+})
+
+// zeroReader is an endless stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,95 @@
+package realitydefender
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces outgoing requests. Wait blocks until a request may be sent
+// or the context is done. *rate.Limiter from golang.org/x/time/rate satisfies it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// tokenBucket is a RateLimiter that refills tokens at a fixed rate up to a burst size
+type tokenBucket struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter creates a token-bucket RateLimiter allowing requestsPerSecond on average
+// with bursts of up to burst requests. A burst below 1 is treated as 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = 1
+	}
+
+	return &tokenBucket{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait implements RateLimiter
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, delay)
+}
+
+// reserve takes a token, possibly going into debt, and returns how long the caller must wait for it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+// It returns zero when the header is missing, malformed or in the past.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,170 @@
+package realitydefender_test
+
+import (
+	"context"
+	"errors"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate Limiting", func() {
+	var server *httptest.Server
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	resultBody := `{"requestId":"test-request-id","resultsSummary":{"status":"AUTHENTIC"},"models":[]}`
+
+	Describe("429 responses", func() {
+		It("maps 429 to a rate limited error with the Retry-After delay", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+
+			client, err := realitydefender.New(realitydefender.Config{
+				APIKey:  "test-api-key",
+				BaseURL: server.URL,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetResult(context.Background(), "test-request-id", nil)
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeRateLimited))
+			Expect(sdkErr.RetryAfter).To(Equal(7 * time.Second))
+		})
+
+		It("parses Retry-After given as an HTTP date", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", time.Now().Add(90*time.Second).UTC().Format(http.TimeFormat))
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+
+			client, err := realitydefender.New(realitydefender.Config{
+				APIKey:  "test-api-key",
+				BaseURL: server.URL,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetResult(context.Background(), "test-request-id", nil)
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.RetryAfter).To(BeNumerically("~", 90*time.Second, 2*time.Second))
+		})
+
+		It("waits for Retry-After before retrying", func() {
+			var calls int32
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(resultBody))
+			}))
+
+			client, err := realitydefender.New(realitydefender.Config{
+				APIKey:  "test-api-key",
+				BaseURL: server.URL,
+				RetryPolicy: &realitydefender.RetryPolicy{
+					MaxAttempts: 2,
+					BaseBackoff: time.Millisecond,
+					MaxBackoff:  2 * time.Second,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			start := time.Now()
+			result, err := client.GetResult(context.Background(), "test-request-id", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+			Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+		})
+
+		It("returns the error when Retry-After exceeds the maximum backoff", func() {
+			var calls int32
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.Header().Set("Retry-After", "120")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+
+			client, err := realitydefender.New(realitydefender.Config{
+				APIKey:      "test-api-key",
+				BaseURL:     server.URL,
+				RetryPolicy: realitydefender.DefaultRetryPolicy(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetResult(context.Background(), "test-request-id", nil)
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeRateLimited))
+			Expect(sdkErr.RetryAfter).To(Equal(120 * time.Second))
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+	})
+
+	Describe("NewRateLimiter", func() {
+		It("allows a burst and then paces requests", func() {
+			limiter := realitydefender.NewRateLimiter(20, 2)
+			ctx := context.Background()
+
+			start := time.Now()
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Wait(ctx)).To(Succeed())
+			}
+			// Two immediate tokens, then two more at 50ms intervals
+			Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
+
+		It("stops waiting when the context is done", func() {
+			limiter := realitydefender.NewRateLimiter(0.1, 1)
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			Expect(limiter.Wait(ctx)).To(MatchError(context.DeadlineExceeded))
+		})
+
+		It("paces every request of a client", func() {
+			var calls int32
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(resultBody))
+			}))
+
+			client, err := realitydefender.New(realitydefender.Config{
+				APIKey:      "test-api-key",
+				BaseURL:     server.URL,
+				RateLimiter: realitydefender.NewRateLimiter(20, 1),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			start := time.Now()
+			for i := 0; i < 3; i++ {
+				_, err := client.GetResult(context.Background(), "test-request-id", nil)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+		})
+	})
+})
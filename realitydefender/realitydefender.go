@@ -5,8 +5,15 @@ package realitydefender
 import (
 	"context"
 	"errors"
-	"sync"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ErrorCode represents error codes returned by the SDK
@@ -22,6 +29,7 @@ const (
 	ErrorCodeFileTooLarge   ErrorCode = "file_too_large"  // File is too large
 	ErrorCodeUploadFailed   ErrorCode = "upload_failed"   // Failed to upload the file
 	ErrorCodeNotFound       ErrorCode = "not_found"       // Requested resource not found
+	ErrorCodeRateLimited    ErrorCode = "rate_limited"    // Too many requests, retry later
 	ErrorCodeUnknownError   ErrorCode = "unknown_error"   // Unexpected error
 )
 
@@ -30,39 +38,165 @@ const (
 	DefaultPollingInterval = 2000  // Default polling interval in milliseconds
 	DefaultTimeout         = 60000 // Default timeout in milliseconds
 	DefaultBaseURL         = "https://api.prd.realitydefender.xyz"
+	DefaultAPITimeout      = 30 * time.Second // Default timeout for each API call
+	DefaultUploadTimeout   = 10 * time.Minute // Default timeout for each presigned URL upload
 )
 
 // SDKError represents an error returned by the SDK
 type SDKError struct {
 	Message string
 	Code    ErrorCode
+	// RetryAfter is how long the server asked the client to wait before retrying (zero if not given)
+	RetryAfter time.Duration
+	// StatusCode is the HTTP status code of the failed response (zero if no response was received)
+	StatusCode int
+	// ServerCode is the error code from the API response body, e.g. "upload-limit-reached"
+	ServerCode string
+	// ErrNo is the numeric error from the API response body
+	ErrNo int
+	// RequestID is the request ID reported with the error, when known
+	RequestID string
+	// Err is the underlying cause, if any
+	Err error
 }
 
+// Sentinel errors for use with errors.Is. An SDKError matches the sentinel with the same Code.
+var (
+	ErrUnauthorized   = &SDKError{Message: "unauthorized", Code: ErrorCodeUnauthorized}
+	ErrInvalidRequest = &SDKError{Message: "invalid request", Code: ErrorCodeInvalidRequest}
+	ErrServerError    = &SDKError{Message: "server error", Code: ErrorCodeServerError}
+	ErrTimeout        = &SDKError{Message: "timeout", Code: ErrorCodeTimeout}
+	ErrInvalidFile    = &SDKError{Message: "invalid file", Code: ErrorCodeInvalidFile}
+	ErrFileTooLarge   = &SDKError{Message: "file too large", Code: ErrorCodeFileTooLarge}
+	ErrUploadFailed   = &SDKError{Message: "upload failed", Code: ErrorCodeUploadFailed}
+	ErrNotFound       = &SDKError{Message: "not found", Code: ErrorCodeNotFound}
+	ErrRateLimited    = &SDKError{Message: "rate limited", Code: ErrorCodeRateLimited}
+	ErrUnknown        = &SDKError{Message: "unknown error", Code: ErrorCodeUnknownError}
+)
+
 // Error implements the error interface
 func (e *SDKError) Error() string {
 	return e.Message + " (Code: " + string(e.Code) + ")"
 }
 
+// Unwrap returns the underlying cause
+func (e *SDKError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is an SDKError with the same Code, so that
+// errors.Is(err, realitydefender.ErrNotFound) works on any not-found error
+func (e *SDKError) Is(target error) bool {
+	t, ok := target.(*SDKError)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// Retryable reports whether the failed operation may succeed if attempted again:
+// rate limiting, timeouts, gateway errors and transient network failures
+func (e *SDKError) Retryable() bool {
+	switch e.Code {
+	case ErrorCodeRateLimited:
+		return true
+	case ErrorCodeTimeout:
+		return !errors.Is(e.Err, context.Canceled)
+	case ErrorCodeServerError, ErrorCodeUploadFailed:
+		switch e.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		case 0:
+			return e.Err != nil && IsRetryableNetworkError(e.Err)
+		}
+	}
+	return false
+}
+
+// wrapError prefixes err with a message while keeping the code and response details of an
+// underlying SDKError. Other errors get the fallback code.
+func wrapError(err error, message string, fallback ErrorCode) *SDKError {
+	wrapped := &SDKError{
+		Message: fmt.Sprintf("%s: %v", message, err),
+		Code:    fallback,
+		Err:     err,
+	}
+
+	var sdkErr *SDKError
+	if errors.As(err, &sdkErr) {
+		wrapped.Code = sdkErr.Code
+		wrapped.RetryAfter = sdkErr.RetryAfter
+		wrapped.StatusCode = sdkErr.StatusCode
+		wrapped.ServerCode = sdkErr.ServerCode
+		wrapped.ErrNo = sdkErr.ErrNo
+		wrapped.RequestID = sdkErr.RequestID
+	}
+	return wrapped
+}
+
 // Config represents configuration options for the Reality Defender SDK
 type Config struct {
 	// APIKey is the authentication key for the API (required)
 	APIKey string
 	// BaseURL is the optional custom base URL for the API (defaults to production)
 	BaseURL string
+	// RetryPolicy enables automatic retries of transient failures (defaults to no retries)
+	RetryPolicy *RetryPolicy
+	// RateLimiter optionally paces every request made by the client, see NewRateLimiter
+	RateLimiter RateLimiter
+	// HTTPClient is an optional HTTP client to send requests with, for proxies, custom TLS
+	// or connection pool tuning. Its Timeout applies to API calls as is, zero meaning none,
+	// unless APITimeout is set; uploads use UploadTimeout.
+	HTTPClient *http.Client
+	// Transport optionally replaces the transport of HTTPClient (or of the default client)
+	Transport http.RoundTripper
+	// APITimeout bounds each API call (defaults to the Timeout of HTTPClient when it is set,
+	// and to DefaultAPITimeout otherwise)
+	APITimeout time.Duration
+	// UploadTimeout bounds each presigned URL upload (defaults to DefaultUploadTimeout)
+	UploadTimeout time.Duration
+	// Middleware wraps every request sent by the client, the first entry being the outermost.
+	// RequestInfoFromContext(req.Context()) tells which endpoint a request is for.
+	Middleware []Middleware
+	// Logger optionally receives structured logs of requests, retries, uploads and polling.
+	// API keys and presigned URL signatures are redacted.
+	Logger *slog.Logger
+	// TracerProvider creates OpenTelemetry spans for uploads, polls, result pages and feedback
+	// (defaults to the global provider, which records nothing unless configured)
+	TracerProvider trace.TracerProvider
+	// MeterProvider creates OpenTelemetry request, retry, upload and detection metrics
+	// (defaults to the global provider)
+	MeterProvider metric.MeterProvider
+	// Propagator injects trace context into request headers (defaults to the global propagator)
+	Propagator propagation.TextMapPropagator
+	// Cache optionally stores results by content hash, so Upload, DetectFile and DetectBatch
+	// reuse the request ID or result of identical media instead of uploading it again.
+	// See NewMemoryCache and NewFileCache.
+	Cache ResultCache
+	// CacheTTL is how long cache entries are used for (defaults to no expiry)
+	CacheTTL time.Duration
+	// AsyncEvents delivers events on a separate goroutine, in the order they were emitted,
+	// so slow handlers do not hold up uploads and polling
+	AsyncEvents bool
+	// Cassette optionally records the client's requests and responses, or replays them
+	// without network access. See NewCassette.
+	Cassette *Cassette
 }
 
 // Client is the main SDK client for interacting with the Reality Defender API
 type Client struct {
-	apiKey      string
-	baseURL     string
-	httpClient  *httpClient
-	eventsMutex sync.RWMutex
-	handlers    map[string][]EventHandler
+	apiKey     string
+	baseURL    string
+	httpClient *httpClient
+	events     *eventBus
+	cache      ResultCache
+	cacheTTL   time.Duration
+	// cacheScope prefixes cache keys with the account and API the client uses
+	cacheScope string
+	// cachedUploads maps request IDs uploaded through the cache to their cache key,
+	// until their final result is cached
+	cachedUploads pendingUploads
+	// uploadLocks serializes uploads through the cache by cache key
+	uploadLocks keyLocks
 }
 
-// EventHandler is a function that handles SDK events
-type EventHandler func(interface{})
-
 // New creates a new Reality Defender SDK client
 func New(config Config) (*Client, error) {
 	if config.APIKey == "" {
@@ -78,55 +212,49 @@ func New(config Config) (*Client, error) {
 	}
 
 	client := &Client{
-		apiKey:   config.APIKey,
-		baseURL:  baseURL,
-		handlers: make(map[string][]EventHandler),
+		apiKey:     config.APIKey,
+		baseURL:    baseURL,
+		events:     newEventBus(config.AsyncEvents),
+		cache:      config.Cache,
+		cacheTTL:   config.CacheTTL,
+		cacheScope: cacheScope(baseURL, config.APIKey),
 	}
 
 	client.httpClient = newHTTPClient(&httpClientConfig{
-		apiKey:  config.APIKey,
-		baseURL: baseURL,
+		apiKey:         config.APIKey,
+		baseURL:        baseURL,
+		retryPolicy:    normalizeRetryPolicy(config.RetryPolicy),
+		rateLimiter:    config.RateLimiter,
+		httpClient:     config.HTTPClient,
+		transport:      config.Transport,
+		apiTimeout:     config.APITimeout,
+		uploadTimeout:  config.UploadTimeout,
+		middleware:     config.Middleware,
+		logger:         config.Logger,
+		tracerProvider: config.TracerProvider,
+		meterProvider:  config.MeterProvider,
+		propagator:     config.Propagator,
+		cassette:       config.Cassette,
 	})
 
 	return client, nil
 }
 
-// On registers an event handler for the specified event
-func (c *Client) On(event string, handler EventHandler) {
-	c.eventsMutex.Lock()
-	defer c.eventsMutex.Unlock()
-
-	if _, exists := c.handlers[event]; !exists {
-		c.handlers[event] = []EventHandler{}
-	}
-
-	c.handlers[event] = append(c.handlers[event], handler)
-}
-
-// emit triggers handlers for the specified event
-func (c *Client) emit(event string, data interface{}) {
-	c.eventsMutex.RLock()
-	defer c.eventsMutex.RUnlock()
-
-	handlers, exists := c.handlers[event]
-	if !exists {
-		return
-	}
-
-	for _, handler := range handlers {
-		handler(data)
-	}
-}
-
 // Upload uploads a file to Reality Defender for analysis
 func (c *Client) Upload(ctx context.Context, options UploadOptions) (*UploadResult, error) {
-	result, err := uploadFile(ctx, c.httpClient, options)
+	result, _, err := c.uploadCached(ctx, options)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// UploadReader streams size bytes read from r to Reality Defender for analysis.
+// The name is used for the file type check and as the uploaded file name.
+func (c *Client) UploadReader(ctx context.Context, name string, r io.Reader, size int64) (*UploadResult, error) {
+	return uploadReader(ctx, c.httpClient, name, r, size, c.withProgressEvents(UploadOptions{}))
+}
+
 // UploadSocialMedia uploads a social media link to Reality Defender for analysis
 func (c *Client) UploadSocialMedia(ctx context.Context, options UploadSocialMediaOptions) (*UploadResult, error) {
 	result, err := uploadSocialMediaLink(ctx, c.httpClient, options)
@@ -147,7 +275,12 @@ func (c *Client) GetResult(ctx context.Context, requestID string, options *GetRe
 		options = &GetResultOptions{}
 	}
 
-	return getDetectionResult(ctx, c.httpClient, requestID, *options)
+	result, err := getDetectionResult(ctx, c.httpClient, requestID, *options, c.emitPollAttempt)
+	if err != nil {
+		return nil, err
+	}
+	c.handleResult(ctx, result)
+	return result, nil
 }
 
 // GetResults queries the detection results stored in the platform
@@ -161,92 +294,113 @@ func (c *Client) GetResults(ctx context.Context, pageNumber *int, size *int, nam
 
 // PollForResults starts polling for results with event-based callback
 func (c *Client) PollForResults(ctx context.Context, requestID string, options *PollOptions) error {
-	pollingInterval := DefaultPollingInterval
-	timeout := DefaultTimeout
-
-	if options != nil {
-		if options.PollingInterval > 0 {
-			pollingInterval = options.PollingInterval
-		}
-		if options.Timeout > 0 {
-			timeout = options.Timeout
-		}
+	if options == nil {
+		options = &PollOptions{}
 	}
 
-	return c.pollForResults(ctx, requestID, pollingInterval, timeout)
+	strategy := pollStrategy(options.Strategy, options.Interval, options.PollingInterval)
+	return c.pollForResults(ctx, requestID, strategy, options.MediaType, pollTimeout(options))
 }
 
-// pollForResults is the internal implementation of polling for results
-func (c *Client) pollForResults(ctx context.Context, requestID string, pollingInterval, timeout int) error {
-	elapsed := 0
-	maxWaitTime := timeout
+// pollForResults is the internal implementation of polling for results. The time spent
+// polling is the sum of the strategy's waits, which is compared against the timeout.
+func (c *Client) pollForResults(ctx context.Context, requestID string, strategy PollStrategy, mediaType MediaType, timeout time.Duration) error {
+	var elapsed time.Duration
 	isCompleted := false
 
 	// Check if timeout is already zero/expired before starting
 	if timeout <= 0 {
-		c.emit("error", &SDKError{
+		c.emit(EventError, &SDKError{
 			Message: "Polling timeout exceeded",
 			Code:    ErrorCodeTimeout,
 		})
 		return errors.New("polling timeout exceeded")
 	}
 
-	for !isCompleted && elapsed < maxWaitTime {
-		select {
-		case <-ctx.Done():
+	for attempt := 1; !isCompleted && elapsed < timeout; attempt++ {
+		// Poll once per iteration so that the strategy alone paces the polls
+		result, err := c.pollOnce(ctx, requestID, attempt)
+		if ctx.Err() != nil {
 			return ctx.Err()
-		default:
-			result, err := c.GetResult(ctx, requestID, nil)
-
-			if err != nil {
-				var sdkErr *SDKError
-				if errors.As(err, &sdkErr) && sdkErr.Code == ErrorCodeNotFound {
-					// Result not ready yet, continue polling if we haven't exceeded the timeout
-					elapsed += pollingInterval
-					time.Sleep(time.Duration(pollingInterval) * time.Millisecond)
-					continue
-				}
-
-				// Any other error is emitted and polling stops
+		}
+
+		if err != nil {
+			var sdkErr *SDKError
+			if !errors.As(err, &sdkErr) || sdkErr.Code != ErrorCodeNotFound {
+				// Any error other than a missing result is emitted and polling stops
 				isCompleted = true
-				c.emit("error", err)
+				c.emit(EventError, err)
 				return err
 			}
 
-			// If the status is still ANALYZING and we haven't exceeded the timeout,
-			// continue polling after a delay
-			if result.Status == "ANALYZING" {
-				elapsed += pollingInterval
-				time.Sleep(time.Duration(pollingInterval) * time.Millisecond)
-			} else {
-				// We have a final result
-				isCompleted = true
-				c.emit("result", result)
-			}
+			// Result not ready yet, continue polling if we haven't exceeded the timeout
+			c.httpClient.logger.DebugContext(ctx, "poll found no result yet", "request_id", requestID, "elapsed", elapsed)
+		} else if result.IsFinal() {
+			// We have a final result
+			isCompleted = true
+			c.handleResult(ctx, result)
+			c.emit(EventResult, result)
+			break
+		} else {
+			c.httpClient.logger.DebugContext(ctx, "poll found result still analyzing", "request_id", requestID, "elapsed", elapsed)
+		}
+
+		// Wait before polling again
+		delay := strategy.NextDelay(PollState{RequestID: requestID, MediaType: mediaType, Attempt: attempt, Elapsed: elapsed})
+		elapsed += delay
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
 		}
 	}
 
 	// Check if we timed out
-	if !isCompleted && elapsed >= maxWaitTime {
+	if !isCompleted {
 		timeoutErr := &SDKError{
 			Message: "Polling timeout exceeded",
 			Code:    ErrorCodeTimeout,
 		}
-		c.emit("error", timeoutErr)
+		c.httpClient.logger.WarnContext(ctx, "polling timed out", "request_id", requestID, "timeout", timeout)
+		c.emit(EventError, timeoutErr)
 		return timeoutErr
 	}
 
 	return nil
 }
 
+// handleResult takes a result fetched from the API. A final result is counted in the
+// detections metric and stored in the result cache.
+func (c *Client) handleResult(ctx context.Context, result *DetectionResult) {
+	if result == nil || !result.IsFinal() {
+		return
+	}
+	c.httpClient.telemetry.recordDetection(ctx, string(result.Status))
+	c.cacheResult(ctx, result)
+}
+
+// pollOnce fetches the result of a request once, reporting the poll to OnPollAttempt handlers
+func (c *Client) pollOnce(ctx context.Context, requestID string, attempt int) (*DetectionResult, error) {
+	start := time.Now()
+	result, err := fetchDetectionResult(ctx, c.httpClient, requestID, attempt)
+
+	report := PollAttempt{RequestID: requestID, Attempt: attempt, Err: err, Duration: time.Since(start)}
+	if result != nil {
+		report.Status = result.Status
+	}
+	c.emitPollAttempt(report)
+	return result, err
+}
+
 // DetectFile is a convenience method to upload and detect a file in one step
 func (c *Client) DetectFile(ctx context.Context, filePath string) (*DetectionResult, error) {
-	uploadResult, err := c.Upload(ctx, UploadOptions{
+	uploadResult, cached, err := c.uploadCached(ctx, UploadOptions{
 		FilePath: filePath,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if cached != nil {
+		return cached, nil
+	}
 
-	return c.GetResult(ctx, uploadResult.RequestID, nil)
+	return c.GetResult(ctx, uploadResult.RequestID, &GetResultOptions{MediaType: MediaTypeOf(filePath)})
 }
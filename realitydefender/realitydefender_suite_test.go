@@ -1,6 +1,7 @@
 package realitydefender_test
 
 import (
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
 	"testing"
 	"time"
 
@@ -27,3 +28,10 @@ func TestRealityDefender(t *testing.T) {
 		t.Fatal("Test execution timed out after", timeout)
 	}
 }
+
+// newFakeServer starts a fake API server that is closed when the spec ends
+func newFakeServer() *realitydefendertest.Server {
+	server := realitydefendertest.NewServer()
+	DeferCleanup(server.Close)
+	return server
+}
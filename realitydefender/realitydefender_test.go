@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
@@ -184,11 +185,11 @@ var _ = Describe("RealityDefender SDK", func() {
 			result, err := client.GetResult(ctx, "test-request-id", nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).NotTo(BeNil())
-			Expect(result.Status).To(Equal("MANIPULATED"))
+			Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Score).To(Equal(0.95))
 			Expect(result.Models).To(HaveLen(1))
 			Expect(result.Models[0].Name).To(Equal("test-model"))
-			Expect(result.Models[0].Status).To(Equal("MANIPULATED"))
+			Expect(result.Models[0].Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Models[0].Score).To(Equal(0.95))
 		})
 	})
@@ -541,23 +542,23 @@ var _ = Describe("RealityDefender SDK", func() {
 				// Verify first item
 				firstItem := result.Items[0]
 				Expect(firstItem.RequestID).To(Equal("test-request-id-1"))
-				Expect(firstItem.Status).To(Equal("MANIPULATED"))
+				Expect(firstItem.Status).To(Equal(realitydefender.StatusManipulated))
 				Expect(firstItem.Score).NotTo(BeNil())
 				Expect(*firstItem.Score).To(Equal(0.95))
 				Expect(firstItem.Models).To(HaveLen(1))
 				Expect(firstItem.Models[0].Name).To(Equal("model1"))
-				Expect(firstItem.Models[0].Status).To(Equal("MANIPULATED"))
+				Expect(firstItem.Models[0].Status).To(Equal(realitydefender.StatusManipulated))
 				Expect(*firstItem.Models[0].Score).To(Equal(0.99))
 
 				// Verify second item
 				secondItem := result.Items[1]
 				Expect(secondItem.RequestID).To(Equal("test-request-id-2"))
-				Expect(secondItem.Status).To(Equal("AUTHENTIC"))
+				Expect(secondItem.Status).To(Equal(realitydefender.StatusAuthentic))
 				Expect(secondItem.Score).NotTo(BeNil())
 				Expect(*secondItem.Score).To(Equal(0.95))
 				Expect(secondItem.Models).To(HaveLen(1))
 				Expect(secondItem.Models[0].Name).To(Equal("model2"))
-				Expect(secondItem.Models[0].Status).To(Equal("AUTHENTIC"))
+				Expect(secondItem.Models[0].Status).To(Equal(realitydefender.StatusAuthentic))
 				Expect(*secondItem.Models[0].Score).To(Equal(0.01))
 			})
 
@@ -642,7 +643,7 @@ var _ = Describe("RealityDefender SDK", func() {
 			result, err := client.DetectFile(ctx, filePath)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).NotTo(BeNil())
-			Expect(result.Status).To(Equal("MANIPULATED"))
+			Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Score).To(Equal(0.95))
 		})
 	})
@@ -816,7 +817,7 @@ var _ = Describe("RealityDefender SDK", func() {
 			}
 
 			Expect(result).NotTo(BeNil())
-			Expect(result.Status).To(Equal("MANIPULATED"))
+			Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*result.Score).To(Equal(0.95))
 		})
 
@@ -841,7 +842,7 @@ var _ = Describe("RealityDefender SDK", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resultReceived).To(BeTrue())
 			Expect(resultData).NotTo(BeNil())
-			Expect(resultData.Status).To(Equal("MANIPULATED"))
+			Expect(resultData.Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(*resultData.Score).To(Equal(0.95))
 		})
 
@@ -971,7 +972,7 @@ var _ = Describe("RealityDefender SDK", func() {
 			// Verify the result is a DetectionResult
 			detectionResult, ok := result.(*realitydefender.DetectionResult)
 			Expect(ok).To(BeTrue())
-			Expect(detectionResult.Status).To(Equal("MANIPULATED"))
+			Expect(detectionResult.Status).To(Equal(realitydefender.StatusManipulated))
 		})
 
 		It("handles multiple registered handlers for the same event", func() {
@@ -1150,7 +1151,40 @@ var _ = Describe("RealityDefender SDK", func() {
 			// Verify the result
 			detectionResult, ok := result.(*realitydefender.DetectionResult)
 			Expect(ok).To(BeTrue())
-			Expect(detectionResult.Status).To(Equal("MANIPULATED"))
+			Expect(detectionResult.Status).To(Equal(realitydefender.StatusManipulated))
+		})
+
+		It("keeps polling while models are still analyzing", func() {
+			var requestCount int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The summary is final before the model is
+				modelStatus := "ANALYZING"
+				if atomic.AddInt32(&requestCount, 1) > 1 {
+					modelStatus = "AUTHENTIC"
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"requestId":"test-request-id","resultsSummary":{"status":"AUTHENTIC"},"models":[{"name":"model1","status":"` + modelStatus + `"}]}`))
+			}))
+			defer server.Close()
+
+			client, err := realitydefender.New(realitydefender.Config{
+				APIKey:  "test-api-key",
+				BaseURL: server.URL,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var result *realitydefender.DetectionResult
+			client.On("result", func(data interface{}) {
+				result = data.(*realitydefender.DetectionResult)
+			})
+
+			err = client.PollForResults(context.Background(), "test-request-id", &realitydefender.PollOptions{
+				PollingInterval: 10,
+				Timeout:         1000,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(2)))
+			Expect(result.Models[0].Status).To(Equal(realitydefender.StatusAuthentic))
 		})
 
 		It("handles polling timeout", func() {
@@ -1198,9 +1232,8 @@ var _ = Describe("RealityDefender SDK", func() {
 				Timeout:         50, // Very short timeout to force timeout error
 			})
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("context deadline exceeded"))
-
-			// No need to check for error event as it won't be triggered with context cancellation
+			Expect(err).To(MatchError(realitydefender.ErrTimeout))
+			Eventually(errorCalled).Should(Receive(MatchError(realitydefender.ErrTimeout)))
 		})
 
 		It("handles context cancellation", func() {
@@ -0,0 +1,126 @@
+package realitydefendertest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+)
+
+// MockAPI is a realitydefender.API whose methods call the function fields. A method whose
+// field is nil fails with an error. Calls are recorded, and MockAPI is safe for concurrent use
+// as long as its fields are not changed during calls.
+type MockAPI struct {
+	UploadFunc             func(ctx context.Context, options realitydefender.UploadOptions) (*realitydefender.UploadResult, error)
+	UploadSocialMediaFunc  func(ctx context.Context, options realitydefender.UploadSocialMediaOptions) (*realitydefender.UploadResult, error)
+	GetResultFunc          func(ctx context.Context, requestID string, options *realitydefender.GetResultOptions) (*realitydefender.DetectionResult, error)
+	GetResultsFunc         func(ctx context.Context, pageNumber *int, size *int, name *string, startDate *time.Time, endDate *time.Time, options *realitydefender.GetResultOptions) (*realitydefender.DetectionResultList, error)
+	PollForResultsFunc     func(ctx context.Context, requestID string, options *realitydefender.PollOptions) error
+	DetectFileFunc         func(ctx context.Context, filePath string) (*realitydefender.DetectionResult, error)
+	CreateUserFeedbackFunc func(ctx context.Context, opts realitydefender.CreateUserFeedbackOptions) (*realitydefender.UserFeedback, error)
+
+	mu    sync.Mutex
+	calls []realitydefender.Call
+}
+
+var _ realitydefender.API = (*MockAPI)(nil)
+
+// Calls returns the calls made so far, in order
+func (m *MockAPI) Calls() []realitydefender.Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]realitydefender.Call(nil), m.calls...)
+}
+
+// CallsTo returns the calls made so far to a method, one of the realitydefender.Method constants
+func (m *MockAPI) CallsTo(method string) []realitydefender.Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []realitydefender.Call
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// record records a call
+func (m *MockAPI) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, realitydefender.Call{Method: method, Args: args})
+}
+
+// notSet is the error of a method whose function field is nil
+func notSet(method string) error {
+	return &realitydefender.SDKError{
+		Message: "MockAPI." + method + "Func is not set",
+		Code:    realitydefender.ErrorCodeUnknownError,
+	}
+}
+
+// Upload implements realitydefender.Uploader
+func (m *MockAPI) Upload(ctx context.Context, options realitydefender.UploadOptions) (*realitydefender.UploadResult, error) {
+	m.record(realitydefender.MethodUpload, options)
+	if m.UploadFunc == nil {
+		return nil, notSet(realitydefender.MethodUpload)
+	}
+	return m.UploadFunc(ctx, options)
+}
+
+// UploadSocialMedia implements realitydefender.Uploader
+func (m *MockAPI) UploadSocialMedia(ctx context.Context, options realitydefender.UploadSocialMediaOptions) (*realitydefender.UploadResult, error) {
+	m.record(realitydefender.MethodUploadSocialMedia, options)
+	if m.UploadSocialMediaFunc == nil {
+		return nil, notSet(realitydefender.MethodUploadSocialMedia)
+	}
+	return m.UploadSocialMediaFunc(ctx, options)
+}
+
+// GetResult implements realitydefender.ResultGetter
+func (m *MockAPI) GetResult(ctx context.Context, requestID string, options *realitydefender.GetResultOptions) (*realitydefender.DetectionResult, error) {
+	m.record(realitydefender.MethodGetResult, requestID, options)
+	if m.GetResultFunc == nil {
+		return nil, notSet(realitydefender.MethodGetResult)
+	}
+	return m.GetResultFunc(ctx, requestID, options)
+}
+
+// GetResults implements realitydefender.ResultGetter
+func (m *MockAPI) GetResults(ctx context.Context, pageNumber *int, size *int, name *string, startDate *time.Time, endDate *time.Time, options *realitydefender.GetResultOptions) (*realitydefender.DetectionResultList, error) {
+	m.record(realitydefender.MethodGetResults, pageNumber, size, name, startDate, endDate, options)
+	if m.GetResultsFunc == nil {
+		return nil, notSet(realitydefender.MethodGetResults)
+	}
+	return m.GetResultsFunc(ctx, pageNumber, size, name, startDate, endDate, options)
+}
+
+// PollForResults implements realitydefender.ResultGetter
+func (m *MockAPI) PollForResults(ctx context.Context, requestID string, options *realitydefender.PollOptions) error {
+	m.record(realitydefender.MethodPollForResults, requestID, options)
+	if m.PollForResultsFunc == nil {
+		return notSet(realitydefender.MethodPollForResults)
+	}
+	return m.PollForResultsFunc(ctx, requestID, options)
+}
+
+// DetectFile implements realitydefender.Detector
+func (m *MockAPI) DetectFile(ctx context.Context, filePath string) (*realitydefender.DetectionResult, error) {
+	m.record(realitydefender.MethodDetectFile, filePath)
+	if m.DetectFileFunc == nil {
+		return nil, notSet(realitydefender.MethodDetectFile)
+	}
+	return m.DetectFileFunc(ctx, filePath)
+}
+
+// CreateUserFeedback implements realitydefender.FeedbackSubmitter
+func (m *MockAPI) CreateUserFeedback(ctx context.Context, opts realitydefender.CreateUserFeedbackOptions) (*realitydefender.UserFeedback, error) {
+	m.record(realitydefender.MethodCreateUserFeedback, opts)
+	if m.CreateUserFeedbackFunc == nil {
+		return nil, notSet(realitydefender.MethodCreateUserFeedback)
+	}
+	return m.CreateUserFeedbackFunc(ctx, opts)
+}
@@ -0,0 +1,43 @@
+package realitydefendertest_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// detect depends only on the Detector, so tests can pass a MockAPI
+func detect(ctx context.Context, detector realitydefender.Detector, path string) (bool, error) {
+	result, err := detector.DetectFile(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	return result.IsManipulated(), nil
+}
+
+var _ = Describe("MockAPI", func() {
+	It("answers with the function fields and records calls", func() {
+		mock := &realitydefendertest.MockAPI{
+			DetectFileFunc: func(_ context.Context, filePath string) (*realitydefender.DetectionResult, error) {
+				return &realitydefender.DetectionResult{Status: realitydefender.StatusManipulated}, nil
+			},
+		}
+
+		manipulated, err := detect(context.Background(), mock, "clip.mp4")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manipulated).To(BeTrue())
+		Expect(mock.Calls()).To(Equal([]realitydefender.Call{{Method: realitydefender.MethodDetectFile, Args: []interface{}{"clip.mp4"}}}))
+	})
+
+	It("fails methods without a function", func() {
+		mock := &realitydefendertest.MockAPI{}
+		_, err := mock.GetResult(context.Background(), "req-1", nil)
+		Expect(err).To(MatchError(realitydefender.ErrUnknown))
+		Expect(err.Error()).To(ContainSubstring("GetResultFunc"))
+		Expect(mock.CallsTo(realitydefender.MethodGetResult)).To(HaveLen(1))
+		Expect(mock.CallsTo(realitydefender.MethodUpload)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,13 @@
+package realitydefendertest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRealityDefenderTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "realitydefendertest Suite")
+}
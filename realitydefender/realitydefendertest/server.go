@@ -0,0 +1,497 @@
+// Package realitydefendertest provides an in-process fake of the Reality Defender API for
+// testing code that uses the SDK. A Server answers the upload, result, social media and
+// feedback endpoints, follows a scripted outcome for each request, and records every
+// request it receives.
+package realitydefendertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+)
+
+// APIKey is the API key of clients created by NewClient and Server.NewClient
+const APIKey = "test-api-key"
+
+// Endpoint identifies an API endpoint served by the fake, by the SDK's endpoint names
+type Endpoint string
+
+// Endpoints served by the fake
+const (
+	EndpointSignedURL    Endpoint = realitydefender.EndpointSignedURL    // POST /api/files/aws-presigned
+	EndpointUpload       Endpoint = realitydefender.EndpointUpload       // PUT to the presigned URL
+	EndpointMediaResult  Endpoint = realitydefender.EndpointMediaResult  // GET /api/media/users/{requestId}
+	EndpointMediaResults Endpoint = realitydefender.EndpointMediaResults // GET /api/v2/media/users/pages/{page}
+	EndpointSocialMedia  Endpoint = realitydefender.EndpointSocialMedia  // POST /api/files/social
+	EndpointUserFeedback Endpoint = realitydefender.EndpointUserFeedback // POST /api/v2/user-feedback
+	EndpointUnknown      Endpoint = "unknown"                            // any other path, answered with 404
+)
+
+// Outcome scripts how the fake answers the polls for one request. The zero value reports
+// FAKE, which the SDK returns as MANIPULATED, on the first poll.
+type Outcome struct {
+	// NotFoundPolls is the number of polls answered 404 before the result exists
+	NotFoundPolls int
+	// AnalyzingPolls is the number of polls answered ANALYZING after that
+	AnalyzingPolls int
+	// Status is the final status as the API reports it, such as FAKE or AUTHENTIC
+	// (defaults to FAKE)
+	Status string
+	// Score is the final score on the API's 0-100 scale, omitted when nil
+	Score *float64
+	// Models are the model results; they report ANALYZING until the result is final, or
+	// longer as set by their AnalyzingPolls
+	Models []Model
+	// ErrorStatus, when set, answers every poll with this HTTP status, such as 401 or 500
+	ErrorStatus int
+}
+
+// Model is the result of one detection model in an Outcome
+type Model struct {
+	// Name is the model name
+	Name string
+	// Status is the final model status as the API reports it, such as FAKE or AUTHENTIC
+	Status string
+	// Score is the final model score on the API's 0-100 scale, omitted when nil
+	Score *float64
+	// Code is the final model code, such as an error code
+	Code string
+	// Data is the final model details as JSON, such as {"frames":[...]}
+	Data json.RawMessage
+	// AnalyzingPolls is the number of polls the model still reports ANALYZING once the
+	// result is final
+	AnalyzingPolls int
+}
+
+// Request is a request received by the fake
+type Request struct {
+	// Endpoint is the endpoint the request was made to
+	Endpoint Endpoint
+	// Method is the HTTP method
+	Method string
+	// Path is the URL path
+	Path string
+	// Query holds the URL query parameters
+	Query url.Values
+	// Header holds the request headers
+	Header http.Header
+	// Body is the request body, including uploaded media
+	Body []byte
+}
+
+// Server is a fake Reality Defender API. Its methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	defaultOutcome Outcome
+	outcomes       map[string]Outcome
+	results        map[string]*result
+	order          []*result
+	failures       map[Endpoint][]int
+	requests       []Request
+	nextID         int
+}
+
+// result is the state of a request known to the fake
+type result struct {
+	requestID string
+	name      string
+	outcome   Outcome
+	uploaded  bool
+	polls     int
+}
+
+// NewServer starts a fake API server. Call Close when done with it.
+func NewServer() *Server {
+	s := &Server{
+		outcomes: map[string]Outcome{},
+		results:  map[string]*result{},
+		failures: map[Endpoint][]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// TB is the part of testing.TB used by NewClient, so that it also works with GinkgoT()
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+	Cleanup(func())
+}
+
+// NewClient starts a fake API server that is closed when the test ends, and returns a client
+// wired to it
+func NewClient(t TB) (*realitydefender.Client, *Server) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient(realitydefender.Config{})
+	if err != nil {
+		t.Fatalf("creating client for fake server: %v", err)
+	}
+	return client, server
+}
+
+// NewClient creates a client wired to the server. The config's BaseURL is replaced, and its
+// APIKey defaults to APIKey.
+func (s *Server) NewClient(config realitydefender.Config) (*realitydefender.Client, error) {
+	if config.APIKey == "" {
+		config.APIKey = APIKey
+	}
+	config.BaseURL = s.URL
+	return realitydefender.New(config)
+}
+
+// SetDefaultOutcome sets the outcome of uploads without an outcome of their own
+func (s *Server) SetDefaultOutcome(outcome Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultOutcome = outcome
+}
+
+// SetOutcome sets the outcome of later uploads of a file name, or of a social media link
+func (s *Server) SetOutcome(name string, outcome Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomes[name] = outcome
+}
+
+// AddResult makes the fake know a request ID that was uploaded earlier, for testing GetResult
+func (s *Server) AddResult(requestID string, outcome Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addResult(&result{requestID: requestID, outcome: outcome, uploaded: true})
+}
+
+// FailNext answers the next times requests to an endpoint with an error of the HTTP status,
+// such as 401, 429 or 500, before serving it normally again
+func (s *Server) FailNext(endpoint Endpoint, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < times; i++ {
+		s.failures[endpoint] = append(s.failures[endpoint], status)
+	}
+}
+
+// Requests returns the requests received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received so far by an endpoint, in order
+func (s *Server) RequestsTo(endpoint Endpoint) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []Request
+	for _, request := range s.requests {
+		if request.Endpoint == endpoint {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// Polls returns how many times the result of a request has been polled
+func (s *Server) Polls(requestID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.results[requestID]; ok {
+		return r.polls
+	}
+	return 0
+}
+
+// addResult registers a request; s.mu must be held
+func (s *Server) addResult(r *result) {
+	if _, ok := s.results[r.requestID]; !ok {
+		s.order = append(s.order, r)
+	}
+	s.results[r.requestID] = r
+}
+
+// newResult registers a new request for a file name or link; s.mu must be held
+func (s *Server) newResult(name string, uploaded bool) *result {
+	s.nextID++
+	outcome, ok := s.outcomes[name]
+	if !ok {
+		outcome = s.defaultOutcome
+	}
+	r := &result{
+		requestID: fmt.Sprintf("request-%d", s.nextID),
+		name:      name,
+		outcome:   outcome,
+		uploaded:  uploaded,
+	}
+	s.addResult(r)
+	return r
+}
+
+// endpointOf classifies a request by its method and path
+func endpointOf(r *http.Request) Endpoint {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/files/aws-presigned":
+		return EndpointSignedURL
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/upload/"):
+		return EndpointUpload
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/media/users/pages/"):
+		return EndpointMediaResults
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/media/users/"):
+		return EndpointMediaResult
+	case r.Method == http.MethodPost && r.URL.Path == "/api/files/social":
+		return EndpointSocialMedia
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/user-feedback":
+		return EndpointUserFeedback
+	default:
+		return EndpointUnknown
+	}
+}
+
+// handle records a request and serves it
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	endpoint := endpointOf(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Endpoint: endpoint,
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Query:    r.URL.Query(),
+		Header:   r.Header.Clone(),
+		Body:     body,
+	})
+
+	if failures := s.failures[endpoint]; len(failures) > 0 {
+		s.failures[endpoint] = failures[1:]
+		writeError(w, failures[0])
+		return
+	}
+
+	switch endpoint {
+	case EndpointSignedURL:
+		s.serveSignedURL(w, body)
+	case EndpointUpload:
+		s.serveUpload(w, strings.TrimPrefix(r.URL.Path, "/upload/"))
+	case EndpointMediaResult:
+		s.serveResult(w, strings.TrimPrefix(r.URL.Path, "/api/media/users/"))
+	case EndpointMediaResults:
+		s.serveResults(w, r)
+	case EndpointSocialMedia:
+		s.serveSocial(w, body)
+	case EndpointUserFeedback:
+		s.serveFeedback(w, body)
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+// serveSignedURL creates a request for an upload and returns its presigned URL
+func (s *Server) serveSignedURL(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		FileName string `json:"fileName"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.FileName == "" {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	r := s.newResult(payload.FileName, false)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"code":      "ok",
+		"response":  map[string]string{"signedUrl": s.URL + "/upload/" + r.requestID + "?X-Amz-Signature=fake-signature"},
+		"errno":     0,
+		"mediaId":   "media-" + strings.TrimPrefix(r.requestID, "request-"),
+		"requestId": r.requestID,
+	})
+}
+
+// serveUpload accepts the media of a request
+func (s *Server) serveUpload(w http.ResponseWriter, requestID string) {
+	r, ok := s.results[requestID]
+	if !ok {
+		writeError(w, http.StatusForbidden)
+		return
+	}
+	r.uploaded = true
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveResult answers a poll for the result of a request
+func (s *Server) serveResult(w http.ResponseWriter, requestID string) {
+	r, ok := s.results[requestID]
+	if !ok || !r.uploaded {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	r.polls++
+	if r.outcome.ErrorStatus != 0 {
+		writeError(w, r.outcome.ErrorStatus)
+		return
+	}
+	if r.polls <= r.outcome.NotFoundPolls {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, r.media())
+}
+
+// serveResults answers a page of results, newest first, filtered by name. Listing a result
+// counts as a poll of it; results that would answer a poll with 404 are not listed.
+func (s *Server) serveResults(w http.ResponseWriter, req *http.Request) {
+	page, err := strconv.Atoi(strings.TrimPrefix(req.URL.Path, "/api/v2/media/users/pages/"))
+	if err != nil || page < 0 {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	size, err := strconv.Atoi(req.URL.Query().Get("size"))
+	if err != nil || size <= 0 {
+		size = 10
+	}
+
+	name := req.URL.Query().Get("name")
+
+	var listed []*result
+	for i := len(s.order) - 1; i >= 0; i-- {
+		r := s.order[i]
+		if r.uploaded && r.outcome.ErrorStatus == 0 && r.polls >= r.outcome.NotFoundPolls && strings.Contains(r.name, name) {
+			listed = append(listed, r)
+		}
+	}
+
+	var items []map[string]any
+	for i := page * size; i < len(listed) && i < (page+1)*size; i++ {
+		listed[i].polls++
+		items = append(items, listed[i].media())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"totalItems":            len(listed),
+		"totalPages":            (len(listed) + size - 1) / size,
+		"currentPage":           page,
+		"currentPageItemsCount": len(items),
+		"mediaList":             items,
+	})
+}
+
+// serveSocial creates a request for a social media link
+func (s *Server) serveSocial(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		SocialLink string `json:"socialLink"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.SocialLink == "" {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	r := s.newResult(payload.SocialLink, true)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"code":      "ok",
+		"response":  "social media link accepted",
+		"errno":     0,
+		"requestId": r.requestID,
+	})
+}
+
+// serveFeedback stores nothing but echoes the feedback back
+func (s *Server) serveFeedback(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		RequestID        string `json:"requestId"`
+		Label            string `json:"label"`
+		FeedbackCategory string `json:"feedbackCategory"`
+		Comment          string `json:"comment"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	s.nextID++
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"id":        fmt.Sprintf("feedback-%d", s.nextID),
+		"requestId": payload.RequestID,
+		"label":     payload.Label,
+		"category":  payload.FeedbackCategory,
+		"text":      payload.Comment,
+	})
+}
+
+// media renders the result as of its current poll count
+func (r *result) media() map[string]any {
+	finalPolls := r.polls - r.outcome.NotFoundPolls - r.outcome.AnalyzingPolls
+	final := finalPolls > 0
+
+	status := string(realitydefender.StatusAnalyzing)
+	var score *float64
+	if final {
+		status = r.outcome.Status
+		if status == "" {
+			status = "FAKE"
+		}
+		score = r.outcome.Score
+	}
+
+	models := make([]map[string]any, 0, len(r.outcome.Models))
+	for _, model := range r.outcome.Models {
+		modelStatus := string(realitydefender.StatusAnalyzing)
+		var modelScore *float64
+		var data json.RawMessage
+		code := ""
+		if finalPolls > model.AnalyzingPolls {
+			modelStatus = model.Status
+			modelScore = model.Score
+			data = model.Data
+			code = model.Code
+		}
+		models = append(models, map[string]any{
+			"name":       model.Name,
+			"status":     modelStatus,
+			"finalScore": modelScore,
+			"data":       data,
+			"code":       code,
+		})
+	}
+
+	return map[string]any{
+		"name":           r.name,
+		"requestId":      r.requestID,
+		"overallStatus":  strings.ToLower(status),
+		"resultsSummary": map[string]any{"status": status, "metadata": map[string]any{"finalScore": score}},
+		"models":         models,
+	}
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error response in the API's format
+func writeError(w http.ResponseWriter, status int) {
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "-")
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "0")
+	}
+	writeJSON(w, status, map[string]any{
+		"code":     code,
+		"response": http.StatusText(status),
+		"errno":    status,
+	})
+}
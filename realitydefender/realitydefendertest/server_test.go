@@ -0,0 +1,190 @@
+package realitydefendertest_test
+
+import (
+	"context"
+	"encoding/json"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		client *realitydefender.Client
+		server *realitydefendertest.Server
+		ctx    context.Context
+		png    = []byte("\x89PNG\r\n\x1a\n")
+		fast   = &realitydefender.GetResultOptions{Strategy: realitydefender.ConstantPoll{Interval: time.Millisecond}}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		client, server = realitydefendertest.NewClient(GinkgoT())
+	})
+
+	score := func(value float64) *float64 {
+		return &value
+	}
+
+	upload := func(fileName string) string {
+		uploaded, err := client.Upload(ctx, realitydefender.UploadOptions{Data: png, FileName: fileName})
+		Expect(err).NotTo(HaveOccurred())
+		return uploaded.RequestID
+	}
+
+	It("reports manipulated media after analyzing", func() {
+		server.SetOutcome("photo.png", realitydefendertest.Outcome{
+			AnalyzingPolls: 2,
+			Status:         "FAKE",
+			Score:          score(95),
+			Models:         []realitydefendertest.Model{{Name: "rd-img", Status: "FAKE", Score: score(97)}},
+		})
+
+		requestID := upload("photo.png")
+		result, err := client.GetResult(ctx, requestID, fast)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(*result.Score).To(BeNumerically("~", 0.95))
+		Expect(result.Models).To(HaveLen(1))
+		Expect(result.Models[0].Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(server.Polls(requestID)).To(Equal(3))
+	})
+
+	It("reports model details once final", func() {
+		server.SetDefaultOutcome(realitydefendertest.Outcome{
+			AnalyzingPolls: 1,
+			Models: []realitydefendertest.Model{{
+				Name:   "rd-video",
+				Status: "FAKE",
+				Code:   "ok",
+				Data:   json.RawMessage(`{"frames":[{"frame":12,"time":0.5,"score":88}]}`),
+			}},
+		})
+
+		result, err := client.GetResult(ctx, upload("photo.png"), fast)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Models[0].Code).To(Equal("ok"))
+		details, err := result.Models[0].Details()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(details.Frames).To(Equal([]realitydefender.FrameScore{{Frame: 12, Time: 0.5, Score: 0.88}}))
+	})
+
+	It("answers 404 until the result exists", func() {
+		server.SetDefaultOutcome(realitydefendertest.Outcome{NotFoundPolls: 2, Status: "AUTHENTIC"})
+
+		result, err := client.GetResult(ctx, upload("photo.png"), fast)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(server.RequestsTo(realitydefendertest.EndpointMediaResult)).To(HaveLen(3))
+	})
+
+	It("keeps models analyzing after the result is final", func() {
+		server.SetDefaultOutcome(realitydefendertest.Outcome{
+			Status: "AUTHENTIC",
+			Models: []realitydefendertest.Model{{Name: "rd-img", Status: "AUTHENTIC", AnalyzingPolls: 2}},
+		})
+
+		requestID := upload("photo.png")
+		result, err := client.GetResult(ctx, requestID, fast)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Models[0].Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(server.Polls(requestID)).To(Equal(3))
+	})
+
+	It("detects a file end to end", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "photo.png")
+		Expect(os.WriteFile(path, png, 0o600)).To(Succeed())
+
+		result, err := client.DetectFile(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
+	})
+
+	DescribeTable("fails requests with API errors",
+		func(endpoint realitydefendertest.Endpoint, status int, expected error) {
+			server.FailNext(endpoint, status, 1)
+			_, err := client.Upload(ctx, realitydefender.UploadOptions{Data: png, FileName: "photo.png"})
+			if endpoint == realitydefendertest.EndpointMediaResult {
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.GetResult(ctx, "request-1", fast)
+			}
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("401 on upload", realitydefendertest.EndpointSignedURL, 401, realitydefender.ErrUnauthorized),
+		Entry("429 on upload", realitydefendertest.EndpointSignedURL, 429, realitydefender.ErrRateLimited),
+		Entry("500 on poll", realitydefendertest.EndpointMediaResult, 500, realitydefender.ErrServerError),
+	)
+
+	It("serves normally again after the scripted failures", func() {
+		server.FailNext(realitydefendertest.EndpointSignedURL, 500, 1)
+		_, err := client.Upload(ctx, realitydefender.UploadOptions{Data: png, FileName: "photo.png"})
+		Expect(err).To(HaveOccurred())
+		upload("photo.png")
+	})
+
+	It("fails every poll of an outcome with an error status", func() {
+		server.AddResult("known", realitydefendertest.Outcome{ErrorStatus: 401})
+		_, err := client.GetResult(ctx, "known", fast)
+		Expect(err).To(MatchError(realitydefender.ErrUnauthorized))
+	})
+
+	It("records requests", func() {
+		requestID := upload("photo.png")
+
+		requests := server.Requests()
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[0].Endpoint).To(Equal(realitydefendertest.EndpointSignedURL))
+		Expect(requests[0].Header.Get("X-API-KEY")).To(Equal(realitydefendertest.APIKey))
+		Expect(string(requests[0].Body)).To(ContainSubstring(`"fileName":"photo.png"`))
+		Expect(requests[1].Endpoint).To(Equal(realitydefendertest.EndpointUpload))
+		Expect(requests[1].Path).To(Equal("/upload/" + requestID))
+		Expect(requests[1].Body).To(Equal(png))
+	})
+
+	It("lists results newest first", func() {
+		server.AddResult("old", realitydefendertest.Outcome{Status: "AUTHENTIC"})
+		server.AddResult("new", realitydefendertest.Outcome{})
+
+		size := 1
+		results, err := client.GetResults(ctx, nil, &size, nil, nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results.TotalItems).To(Equal(2))
+		Expect(results.TotalPages).To(Equal(2))
+		Expect(results.Items).To(HaveLen(1))
+		Expect(results.Items[0].RequestID).To(Equal("new"))
+		Expect(server.RequestsTo(realitydefendertest.EndpointMediaResults)[0].Query.Get("size")).To(Equal("1"))
+	})
+
+	It("accepts social media links and feedback", func() {
+		link := "https://www.youtube.com/watch?v=example"
+		server.SetOutcome(link, realitydefendertest.Outcome{Status: "AUTHENTIC"})
+
+		uploaded, err := client.UploadSocialMedia(ctx, realitydefender.UploadSocialMediaOptions{SocialLink: link})
+		Expect(err).NotTo(HaveOccurred())
+		result, err := client.GetResult(ctx, uploaded.RequestID, fast)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+
+		feedback, err := client.CreateUserFeedback(ctx, realitydefender.CreateUserFeedbackOptions{
+			RequestID:        uploaded.RequestID,
+			Label:            "REAL",
+			FeedbackCategory: "CONFIRMATION",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(feedback.RequestID).To(Equal(uploaded.RequestID))
+		Expect(server.RequestsTo(realitydefendertest.EndpointUserFeedback)).To(HaveLen(1))
+	})
+
+	It("creates clients with their own config", func() {
+		other, err := server.NewClient(realitydefender.Config{APIKey: "other-key"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = other.Upload(ctx, realitydefender.UploadOptions{Data: png, FileName: "photo.png"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.Requests()[0].Header.Get("X-API-KEY")).To(Equal("other-key"))
+	})
+})
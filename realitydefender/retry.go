@@ -0,0 +1,198 @@
+package realitydefender
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// Default retry values used by DefaultRetryPolicy and for unset RetryPolicy fields
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryBaseBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff  = 10 * time.Second
+	DefaultRetryJitter      = 0.2
+)
+
+// errBodyNotRewindable is returned by a bodyFunc whose body cannot be sent again
+var errBodyNotRewindable = errors.New("request body cannot be replayed")
+
+// RetryPolicy configures automatic retries of transient HTTP failures.
+//
+// GET and PUT requests are retried on any retryable status code or network error.
+// POST requests are not idempotent, so to avoid creating duplicate uploads they are only
+// retried when the request provably never reached the server: connection failures before
+// the request was written, and 429 Too Many Requests or 503 Service Unavailable responses.
+// A Retry-After header on the response extends the wait before the next attempt; when it
+// exceeds MaxBackoff the error is returned instead, with the wait in SDKError.RetryAfter.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry; it doubles on every further retry
+	BaseBackoff time.Duration
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0-1) of each wait that is randomized to spread out retries
+	Jitter float64
+	// RetryableStatusCodes lists the response status codes that are retried
+	// (defaults to 429, 502, 503 and 504)
+	RetryableStatusCodes []int
+	// RetryableError decides whether a network error is retried
+	// (defaults to IsRetryableNetworkError)
+	RetryableError func(err error) bool
+}
+
+// DefaultRetryPolicy returns a policy with three attempts and exponential backoff with jitter
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseBackoff: DefaultRetryBaseBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+		Jitter:      DefaultRetryJitter,
+	}
+}
+
+// normalizeRetryPolicy fills unset fields with defaults; a nil policy disables retries
+func normalizeRetryPolicy(policy *RetryPolicy) RetryPolicy {
+	if policy == nil {
+		return RetryPolicy{MaxAttempts: 1}
+	}
+
+	normalized := *policy
+	if normalized.MaxAttempts < 1 {
+		normalized.MaxAttempts = 1
+	}
+	if normalized.BaseBackoff <= 0 {
+		normalized.BaseBackoff = DefaultRetryBaseBackoff
+	}
+	if normalized.MaxBackoff <= 0 {
+		normalized.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if normalized.MaxBackoff < normalized.BaseBackoff {
+		normalized.MaxBackoff = normalized.BaseBackoff
+	}
+	if normalized.Jitter < 0 {
+		normalized.Jitter = 0
+	}
+	if normalized.Jitter > 1 {
+		normalized.Jitter = 1
+	}
+	if normalized.RetryableStatusCodes == nil {
+		normalized.RetryableStatusCodes = []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		}
+	}
+	if normalized.RetryableError == nil {
+		normalized.RetryableError = IsRetryableNetworkError
+	}
+	return normalized
+}
+
+// shouldRetry reports whether the outcome of an attempt is worth another attempt
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	idempotent := method != http.MethodPost
+
+	if err != nil {
+		if !p.RetryableError(err) {
+			return false
+		}
+		return idempotent || !requestWasSent(err)
+	}
+
+	if !p.retryableStatus(resp.StatusCode) {
+		return false
+	}
+	return idempotent || resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// retryableStatus reports whether the status code is in the retryable list
+func (p RetryPolicy) retryableStatus(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the attempt following the given one
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// IsRetryableNetworkError reports whether a transport error is likely transient:
+// timeouts, refused or reset connections and connections closed mid-response.
+func IsRetryableNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// requestWasSent reports whether a failed request may have reached the server.
+// Only failures while establishing the connection guarantee that it did not.
+func requestWasSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return !errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// fitsDeadline reports whether waiting for delay still leaves time before the context deadline
+func fitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,280 @@
+package realitydefender_test
+
+import (
+	"context"
+	"errors"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry Policy", func() {
+	var (
+		server *httptest.Server
+		policy *realitydefender.RetryPolicy
+	)
+
+	BeforeEach(func() {
+		policy = &realitydefender.RetryPolicy{
+			MaxAttempts: 3,
+			BaseBackoff: time.Millisecond,
+			MaxBackoff:  5 * time.Millisecond,
+			Jitter:      0.5,
+		}
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	newClient := func() *realitydefender.Client {
+		client, err := realitydefender.New(realitydefender.Config{
+			APIKey:      "test-api-key",
+			BaseURL:     server.URL,
+			RetryPolicy: policy,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return client
+	}
+
+	resultBody := `{"requestId":"test-request-id","resultsSummary":{"status":"AUTHENTIC"},"models":[]}`
+
+	It("retries GET requests on retryable status codes", func() {
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(resultBody))
+		}))
+
+		result, err := newClient().GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+	})
+
+	It("stops after MaxAttempts", func() {
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}))
+
+		_, err := newClient().GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+	})
+
+	It("does not retry non-retryable status codes", func() {
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+		_, err := newClient().GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	It("honors custom retryable status codes", func() {
+		policy.RetryableStatusCodes = []int{http.StatusInternalServerError}
+
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(resultBody))
+		}))
+
+		_, err := newClient().GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+	})
+
+	It("makes a single attempt without a policy", func() {
+		policy = nil
+
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		_, err := newClient().GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	It("does not retry POST requests the server may have processed", func() {
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+
+		_, err := newClient().UploadSocialMedia(context.Background(), realitydefender.UploadSocialMediaOptions{
+			SocialLink: "https://www.youtube.com/watch?v=test",
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	It("retries POST requests rejected with 503", func() {
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			Expect(string(body)).To(ContainSubstring("socialLink"))
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"requestId":"social-request-id"}`))
+		}))
+
+		result, err := newClient().UploadSocialMedia(context.Background(), realitydefender.UploadSocialMediaOptions{
+			SocialLink: "https://www.youtube.com/watch?v=test",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequestID).To(Equal("social-request-id"))
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+	})
+
+	Describe("uploads", func() {
+		var putCalls int32
+
+		BeforeEach(func() {
+			putCalls = 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/files/aws-presigned":
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"response":{"signedUrl":"` + server.URL + `/upload"},"mediaId":"test-media-id","requestId":"test-request-id"}`))
+				case "/upload":
+					body, _ := io.ReadAll(r.Body)
+					Expect(string(body)).To(Equal("retried content"))
+					if atomic.AddInt32(&putCalls, 1) == 1 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				}
+			}))
+		})
+
+		It("replays seekable bodies", func() {
+			result, err := newClient().Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "retry.txt",
+				Data:     []byte("retried content"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequestID).To(Equal("test-request-id"))
+			Expect(atomic.LoadInt32(&putCalls)).To(Equal(int32(2)))
+		})
+
+		It("does not retry bodies that cannot be replayed", func() {
+			_, err := newClient().Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "retry.txt",
+				Reader:   io.MultiReader(strings.NewReader("retried content")),
+				Size:     int64(len("retried content")),
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeUploadFailed))
+			Expect(atomic.LoadInt32(&putCalls)).To(Equal(int32(1)))
+		})
+	})
+
+	It("does not rewind the body for a retry it abandons", func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/files/aws-presigned":
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"response":{"signedUrl":"` + server.URL + `/upload"},"mediaId":"test-media-id","requestId":"test-request-id"}`))
+			case "/upload":
+				_, _ = io.Copy(io.Discard, r.Body)
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+
+		reader := &seekCounter{Reader: strings.NewReader("retried content")}
+		_, err := newClient().Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "retry.txt",
+			Reader:   reader,
+			Size:     int64(len("retried content")),
+		})
+
+		var sdkErr *realitydefender.SDKError
+		Expect(errors.As(err, &sdkErr)).To(BeTrue())
+		Expect(sdkErr.RetryAfter).To(Equal(time.Minute))
+		// Only the initial position check, never a rewind
+		Expect(atomic.LoadInt32(&reader.seeks)).To(Equal(int32(1)))
+	})
+
+	It("does not wait past the context deadline", func() {
+		policy.BaseBackoff = time.Second
+		policy.MaxBackoff = time.Second
+
+		var calls int32
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := newClient().GetResult(ctx, "test-request-id", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	It("retries connection failures", func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		start := time.Now()
+		_, err := newClient().GetResult(context.Background(), "test-request-id", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("request failed"))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+
+	It("classifies network errors", func() {
+		Expect(realitydefender.IsRetryableNetworkError(io.ErrUnexpectedEOF)).To(BeTrue())
+		Expect(realitydefender.IsRetryableNetworkError(context.Canceled)).To(BeFalse())
+		Expect(realitydefender.IsRetryableNetworkError(errors.New("bad certificate"))).To(BeFalse())
+		Expect(realitydefender.IsRetryableNetworkError(nil)).To(BeFalse())
+	})
+})
+
+// seekCounter counts the seeks on a reader
+type seekCounter struct {
+	*strings.Reader
+	seeks int32
+}
+
+func (s *seekCounter) Seek(offset int64, whence int) (int64, error) {
+	atomic.AddInt32(&s.seeks, 1)
+	return s.Reader.Seek(offset, whence)
+}
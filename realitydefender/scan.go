@@ -0,0 +1,190 @@
+package realitydefender
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ScanOptions configures ScanDirectory
+type ScanOptions struct {
+	// Include limits the scan to files matching at least one glob pattern (default all files).
+	// Patterns containing a slash match the slash-separated path relative to the root;
+	// other patterns match the file name. Syntax is that of path.Match.
+	Include []string
+	// Exclude skips files and directories matching any glob pattern, with the same rules as Include
+	Exclude []string
+	// FollowSymlinks follows symbolic links to files and directories. Links are skipped otherwise.
+	FollowSymlinks bool
+	// Batch configures upload and poll concurrency for the scanned files
+	Batch BatchOptions
+}
+
+// ScanResult is the outcome of ScanDirectory
+type ScanResult struct {
+	// Results maps the slash-separated path of each scanned file, relative to the root, to its result
+	Results map[string]BatchItemResult
+	// Skipped maps relative paths that were not uploaded to the reason: an unsupported
+	// file type, a file over its size limit or an unreadable file or directory
+	Skipped map[string]error
+	// Summary aggregates the results
+	Summary *BatchSummary
+}
+
+// ScanDirectory walks the tree under root and detects every supported file that passes the
+// include and exclude patterns. Files of unsupported types or over their size limit are
+// skipped without being uploaded.
+func (c *Client) ScanDirectory(ctx context.Context, root string, options ScanOptions) (*ScanResult, error) {
+	for _, pattern := range append(append([]string{}, options.Include...), options.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, &SDKError{
+				Message: fmt.Sprintf("invalid pattern %q: %v", pattern, err),
+				Code:    ErrorCodeInvalidRequest,
+				Err:     err,
+			}
+		}
+	}
+
+	info, err := os.Stat(root)
+	if err != nil || !info.IsDir() {
+		return nil, &SDKError{
+			Message: fmt.Sprintf("directory not found: %s", root),
+			Code:    ErrorCodeInvalidRequest,
+		}
+	}
+
+	scanner := &directoryScanner{
+		options: options,
+		skipped: map[string]error{},
+		visited: map[string]bool{},
+	}
+	if realRoot, err := filepath.EvalSymlinks(root); err == nil {
+		scanner.visited[realRoot] = true
+	}
+	scanner.walk(root, "")
+
+	inputs := make([]UploadOptions, len(scanner.files))
+	for i, rel := range scanner.files {
+		inputs[i] = UploadOptions{FilePath: filepath.Join(root, filepath.FromSlash(rel))}
+	}
+
+	c.httpClient.logger.InfoContext(ctx, "scanning directory",
+		"root", root,
+		"files", len(inputs),
+		"skipped", len(scanner.skipped),
+	)
+
+	batch := c.DetectBatch(ctx, inputs, options.Batch)
+	results := make(map[string]BatchItemResult, len(inputs))
+	for item := range batch.Results() {
+		results[scanner.files[item.Index]] = item
+	}
+
+	return &ScanResult{
+		Results: results,
+		Skipped: scanner.skipped,
+		Summary: batch.Wait(),
+	}, nil
+}
+
+// directoryScanner collects the files to upload from a directory tree
+type directoryScanner struct {
+	options ScanOptions
+	files   []string
+	skipped map[string]error
+	// visited holds the resolved paths of directories already walked, to break symlink cycles
+	visited map[string]bool
+}
+
+// walk visits the directory dir, whose path relative to the root is rel
+func (s *directoryScanner) walk(dir, rel string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		s.skipped[relOrDot(rel)] = err
+		return
+	}
+
+	for _, entry := range entries {
+		entryRel := path.Join(rel, entry.Name())
+		entryPath := filepath.Join(dir, entry.Name())
+		if matchesAny(s.options.Exclude, entryRel) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err == nil && entry.Type()&fs.ModeSymlink != 0 {
+			if !s.options.FollowSymlinks {
+				continue
+			}
+			info, err = os.Stat(entryPath)
+		}
+		if err != nil {
+			s.skipped[entryRel] = err
+			continue
+		}
+
+		if info.IsDir() {
+			realPath, err := filepath.EvalSymlinks(entryPath)
+			if err != nil {
+				s.skipped[entryRel] = err
+				continue
+			}
+			if s.visited[realPath] {
+				continue
+			}
+			s.visited[realPath] = true
+			s.walk(entryPath, entryRel)
+			continue
+		}
+
+		if !info.Mode().IsRegular() || (len(s.options.Include) > 0 && !matchesAny(s.options.Include, entryRel)) {
+			continue
+		}
+
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		sizeLimit := fileSizeLimit(extension)
+		if sizeLimit == 0 {
+			s.skipped[entryRel] = &SDKError{
+				Message: fmt.Sprintf("Unsupported file type: %s", extension),
+				Code:    ErrorCodeInvalidFile,
+			}
+			continue
+		}
+		if info.Size() > sizeLimit {
+			s.skipped[entryRel] = &SDKError{
+				Message: fmt.Sprintf("File too large to upload: %s", entryRel),
+				Code:    ErrorCodeFileTooLarge,
+			}
+			continue
+		}
+
+		s.files = append(s.files, entryRel)
+	}
+}
+
+// matchesAny reports whether the relative path matches any of the glob patterns.
+// Patterns without a slash are matched against the base name only.
+func matchesAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// relOrDot returns rel, or "." for the root
+func relOrDot(rel string) string {
+	if rel == "" {
+		return "."
+	}
+	return rel
+}
@@ -0,0 +1,102 @@
+package realitydefender_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScanDirectory", func() {
+	var (
+		server *realitydefendertest.Server
+		client *realitydefender.Client
+		root   string
+	)
+
+	write := func(rel string, content string) {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		server = newFakeServer()
+		server.SetDefaultOutcome(realitydefendertest.Outcome{Status: "AUTHENTIC"})
+
+		var err error
+		client, err = server.NewClient(realitydefender.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		base := GinkgoT().TempDir()
+		root = filepath.Join(base, "root")
+		write("photo.jpg", "content")
+		write("notes.txt", "content")
+		write("report.pdf", "content")
+		write("sub/clip.mp4", "content")
+		write("sub/skip-me.jpg", "content")
+		write("node_modules/dep.jpg", "content")
+		Expect(os.WriteFile(filepath.Join(base, "outside.jpg"), []byte("content"), 0o600)).To(Succeed())
+		Expect(os.Symlink(filepath.Join(base, "outside.jpg"), filepath.Join(root, "linked.jpg"))).To(Succeed())
+		Expect(os.Symlink(root, filepath.Join(root, "sub", "loop"))).To(Succeed())
+
+		big := filepath.Join(root, "big.txt")
+		Expect(os.WriteFile(big, nil, 0o600)).To(Succeed())
+		Expect(os.Truncate(big, 6*1024*1024)).To(Succeed())
+	})
+
+	It("detects supported files keyed by relative path", func() {
+		result, err := client.ScanDirectory(context.Background(), root, realitydefender.ScanOptions{
+			Exclude: []string{"node_modules", "skip-*"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Results).To(HaveLen(3))
+		Expect(result.Results).To(HaveKey("photo.jpg"))
+		Expect(result.Results).To(HaveKey("notes.txt"))
+		Expect(result.Results).To(HaveKey("sub/clip.mp4"))
+		Expect(result.Results["sub/clip.mp4"].Result.Status).To(Equal(realitydefender.StatusAuthentic))
+		Expect(result.Summary.Succeeded).To(Equal(3))
+
+		Expect(result.Skipped).To(HaveLen(2))
+		Expect(result.Skipped["report.pdf"]).To(MatchError(realitydefender.ErrInvalidFile))
+		Expect(result.Skipped["big.txt"]).To(MatchError(realitydefender.ErrFileTooLarge))
+	})
+
+	It("applies include patterns to names and relative paths", func() {
+		result, err := client.ScanDirectory(context.Background(), root, realitydefender.ScanOptions{
+			Include: []string{"*.jpg", "sub/*.mp4"},
+			Exclude: []string{"node_modules"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var paths []string
+		for path := range result.Results {
+			paths = append(paths, path)
+		}
+		Expect(paths).To(ConsistOf("photo.jpg", "sub/clip.mp4", "sub/skip-me.jpg"))
+	})
+
+	It("follows symlinks when asked, without looping", func() {
+		result, err := client.ScanDirectory(context.Background(), root, realitydefender.ScanOptions{
+			Include:        []string{"*.jpg"},
+			Exclude:        []string{"node_modules", "skip-*"},
+			FollowSymlinks: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Results).To(HaveLen(2))
+		Expect(result.Results).To(HaveKey("linked.jpg"))
+	})
+
+	It("rejects missing roots and bad patterns", func() {
+		_, err := client.ScanDirectory(context.Background(), filepath.Join(root, "missing"), realitydefender.ScanOptions{})
+		Expect(err).To(MatchError(realitydefender.ErrInvalidRequest))
+
+		_, err = client.ScanDirectory(context.Background(), root, realitydefender.ScanOptions{Include: []string{"[a-"}})
+		Expect(err).To(MatchError(ContainSubstring("invalid pattern")))
+	})
+})
@@ -0,0 +1,160 @@
+package realitydefender
+
+import (
+	"bytes"
+)
+
+// sniffLen is the number of leading bytes inspected to detect the media type
+const sniffLen = 512
+
+// textFileType is the extension of text uploads
+const textFileType = ".txt"
+
+// DetectFileType identifies media from its leading bytes and returns the canonical
+// file extension (e.g. ".jpg", ".mp4"), or an empty string if the content is not recognized.
+// Detected extensions are not necessarily supported; check them against SupportedFileTypes.
+func DetectFileType(header []byte) string {
+	extension, _ := detectFileType(header)
+	return extension
+}
+
+// detectFileType is DetectFileType, also reporting whether the match is weak: one that rests
+// on a few header bits that text can contain by chance
+func detectFileType(header []byte) (extension string, weak bool) {
+	switch {
+	case bytes.HasPrefix(header, []byte{0xFF, 0xD8, 0xFF}):
+		return ".jpg", false
+	case bytes.HasPrefix(header, []byte("\x89PNG\r\n\x1a\n")):
+		return ".png", false
+	case bytes.HasPrefix(header, []byte("GIF87a")), bytes.HasPrefix(header, []byte("GIF89a")):
+		return ".gif", false
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return ".flac", false
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return ".ogg", false
+	case isID3(header):
+		return ".mp3", true
+	case bytes.HasPrefix(header, []byte("%PDF-")):
+		return ".pdf", false
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")):
+		return ".zip", false
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		// EBML header, shared by WebM and Matroska
+		if bytes.Contains(header, []byte("webm")) {
+			return ".webm", false
+		}
+		return ".mkv", false
+	case len(header) >= 12 && bytes.HasPrefix(header, []byte("RIFF")):
+		return detectRIFF(header[8:12]), false
+	case len(header) >= 12 && bytes.Equal(header[4:8], []byte("ftyp")):
+		return detectFtyp(header[8:12]), false
+	case bytes.HasPrefix(header, []byte{0xFF, 0xFE}):
+		// UTF-16LE byte order mark, which also reads as an MPEG-1 Layer I frame
+		return "", false
+	case len(header) >= 3 && header[0] == 0xFF:
+		extension := detectFrameSync(header[1], header[2])
+		return extension, extension != ""
+	}
+
+	return "", false
+}
+
+// isID3 reports whether the header starts with an ID3v2 tag: a version of 2 to 4, a revision
+// and a size whose bytes each leave the top bit clear
+func isID3(header []byte) bool {
+	if len(header) < 10 || !bytes.HasPrefix(header, []byte("ID3")) {
+		return false
+	}
+	if header[3] < 2 || header[3] > 4 || header[4] == 0xFF {
+		return false
+	}
+	for _, b := range header[6:10] {
+		if b&0x80 != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// detectRIFF maps a RIFF form type to an extension
+func detectRIFF(form []byte) string {
+	switch string(form) {
+	case "WAVE":
+		return ".wav"
+	case "WEBP":
+		return ".webp"
+	case "AVI ":
+		return ".avi"
+	}
+	return ""
+}
+
+// detectFtyp maps an ISO base media major brand to an extension
+func detectFtyp(brand []byte) string {
+	switch string(brand) {
+	case "qt  ":
+		return ".mov"
+	case "M4A ", "M4B ", "M4P ":
+		return ".m4a"
+	case "heic", "heix", "hevc", "heim", "heis", "mif1", "msf1":
+		return ".heic"
+	case "avif", "avis":
+		return ".avif"
+	}
+	return ".mp4"
+}
+
+// detectFrameSync distinguishes ADTS AAC from MPEG audio frames by the second and third
+// header bytes, rejecting reserved field values
+func detectFrameSync(b1, b2 byte) string {
+	switch {
+	case b1&0xF6 == 0xF0:
+		// Sync bits set and layer 00: ADTS, with a sampling frequency index below 13
+		if (b2>>2)&0x0F < 13 {
+			return ".aac"
+		}
+	case b1&0xE0 == 0xE0 && b1&0x18 != 0x08 && b1&0x06 != 0:
+		// Sync bits set with a valid MPEG audio version and layer, then a bitrate index other
+		// than free (0000) or bad (1111) and a sample rate other than reserved (11)
+		if bitrate := b2 >> 4; bitrate != 0x0 && bitrate != 0xF && b2&0x0C != 0x0C {
+			return ".mp3"
+		}
+	}
+	return ""
+}
+
+// equivalentFileTypes groups extensions that describe the same container format
+var equivalentFileTypes = [][]string{
+	{".jpg", ".jpeg"},
+	{".mp4", ".mov", ".m4a", ".alac"},
+}
+
+// sameFileType reports whether two extensions describe the same kind of content
+func sameFileType(a, b string) bool {
+	if a == b {
+		return true
+	}
+	for _, group := range equivalentFileTypes {
+		var hasA, hasB bool
+		for _, ext := range group {
+			hasA = hasA || ext == a
+			hasB = hasB || ext == b
+		}
+		if hasA && hasB {
+			return true
+		}
+	}
+	return false
+}
+
+// fileSizeLimit returns the size limit for an extension, or 0 if the extension is not supported
+func fileSizeLimit(extension string) int64 {
+	for _, fileType := range SupportedFileTypes {
+		for _, ext := range fileType.Extensions {
+			if ext == extension {
+				return fileType.SizeLimit
+			}
+		}
+	}
+	return 0
+}
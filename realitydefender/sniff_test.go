@@ -0,0 +1,170 @@
+package realitydefender_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Content Sniffing", func() {
+	DescribeTable("DetectFileType",
+		func(header []byte, expected string) {
+			Expect(realitydefender.DetectFileType(header)).To(Equal(expected))
+		},
+		Entry("JPEG", []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10}, ".jpg"),
+		Entry("PNG", []byte("\x89PNG\r\n\x1a\n\x00\x00"), ".png"),
+		Entry("GIF", []byte("GIF89a\x01\x00"), ".gif"),
+		Entry("WebP", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), ".webp"),
+		Entry("WAV", []byte("RIFF\x00\x00\x00\x00WAVEfmt "), ".wav"),
+		Entry("FLAC", []byte("fLaC\x00\x00\x00\x22"), ".flac"),
+		Entry("OGG", []byte("OggS\x00\x02"), ".ogg"),
+		Entry("MP3 with ID3 tag", []byte("ID3\x03\x00\x00\x00\x00\x02\x01"), ".mp3"),
+		Entry("MP3 frame", []byte{0xFF, 0xFB, 0x90, 0x64}, ".mp3"),
+		Entry("AAC ADTS frame", []byte{0xFF, 0xF1, 0x50, 0x80}, ".aac"),
+		Entry("MP4", []byte("\x00\x00\x00\x20ftypisom\x00\x00\x02\x00"), ".mp4"),
+		Entry("MOV", []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00"), ".mov"),
+		Entry("M4A", []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"), ".m4a"),
+		Entry("WebM", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\x82\x84webm"), ".webm"),
+		Entry("plain text", []byte("hello world"), ""),
+		Entry("text starting with ID3", []byte("ID3 tags hold song titles"), ""),
+		Entry("UTF-16LE text", []byte("\xFF\xFEh\x00i\x00"), ""),
+		Entry("MPEG frame with a bad bitrate index", []byte{0xFF, 0xFB, 0xF0, 0x64}, ""),
+		Entry("MPEG frame with a free bitrate index", []byte{0xFF, 0xFB, 0x00, 0x64}, ""),
+		Entry("MPEG frame with a reserved sample rate", []byte{0xFF, 0xFB, 0x9C, 0x64}, ""),
+		Entry("MPEG frame with a reserved version", []byte{0xFF, 0xEB, 0x90, 0x64}, ""),
+		Entry("ADTS frame with a reserved sampling frequency", []byte{0xFF, 0xF1, 0x74, 0x80}, ""),
+		Entry("empty header", []byte{}, ""),
+	)
+
+	Describe("Upload validation", func() {
+		var (
+			server *realitydefendertest.Server
+			client *realitydefender.Client
+		)
+
+		BeforeEach(func() {
+			server = newFakeServer()
+
+			var err error
+			client, err = server.NewClient(realitydefender.Config{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// uploadedName is the file name sent for the last upload, empty if nothing was uploaded
+		uploadedName := func() string {
+			requests := server.RequestsTo(realitydefendertest.EndpointSignedURL)
+			if len(requests) == 0 {
+				return ""
+			}
+			var payload map[string]string
+			Expect(json.Unmarshal(requests[len(requests)-1].Body, &payload)).To(Succeed())
+			return payload["fileName"]
+		}
+
+		jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F'}
+		webm := []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\x82\x84webm")
+
+		It("accepts extensionless media and names it by detected type", func() {
+			result, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "snapshot",
+				Data:     jpeg,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequestID).To(Equal("request-1"))
+			Expect(uploadedName()).To(Equal("snapshot.jpg"))
+		})
+
+		It("rejects unsupported content hidden behind a supported extension", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "renamed.mp4",
+				Data:     webm,
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidFile))
+			Expect(sdkErr.Message).To(ContainSubstring("Unsupported file type: .webm"))
+			Expect(uploadedName()).To(BeEmpty())
+		})
+
+		It("treats equivalent extensions as matching", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName:           "photo.jpeg",
+				Data:               jpeg,
+				RejectTypeMismatch: true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploadedName()).To(Equal("photo.jpeg"))
+		})
+
+		It("allows mismatched supported types by default", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "photo.png",
+				Data:     jpeg,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploadedName()).To(Equal("photo.png"))
+		})
+
+		It("rejects mismatches when requested", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName:           "photo.png",
+				Data:               jpeg,
+				RejectTypeMismatch: true,
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeInvalidFile))
+			Expect(sdkErr.Message).To(ContainSubstring("does not match extension"))
+		})
+
+		It("keeps the text extension of text that looks like an audio frame", func() {
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName:           "notes.txt",
+				Data:               []byte{0xFF, 0xFB, 0x90, 0x64, 'n', 'o', 't', 'e', 's'},
+				RejectTypeMismatch: true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploadedName()).To(Equal("notes.txt"))
+		})
+
+		It("applies the size limit of the detected type", func() {
+			// A WAV file is limited to the audio size limit even when named as video
+			wav := make([]byte, 20971521)
+			copy(wav, "RIFF\x00\x00\x00\x00WAVEfmt ")
+
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "clip.mp4",
+				Data:     wav,
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeFileTooLarge))
+		})
+
+		It("keeps the audio size limit of an .m4a file in an MP4 container", func() {
+			m4a := make([]byte, 20971521)
+			copy(m4a, "\x00\x00\x00\x20ftypisom")
+
+			_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+				FileName: "voice.m4a",
+				Data:     m4a,
+			})
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeFileTooLarge))
+		})
+	})
+})
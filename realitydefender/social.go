@@ -28,6 +28,7 @@ func uploadSocialMediaLink(ctx context.Context, client *httpClient, options Uplo
 		return nil, &SDKError{
 			Message: fmt.Sprintf("Invalid social media link: %v", err),
 			Code:    ErrorCodeInvalidRequest,
+			Err:     err,
 		}
 	}
 
@@ -45,10 +46,7 @@ func uploadSocialMediaLink(ctx context.Context, client *httpClient, options Uplo
 
 	responseData, err := client.post(ctx, socialMediaEndpoint, payload)
 	if err != nil {
-		return nil, &SDKError{
-			Message: fmt.Sprintf("Social media link upload failed:: %v", err),
-			Code:    ErrorCodeUploadFailed,
-		}
+		return nil, wrapError(err, "Social media link upload failed", ErrorCodeUploadFailed)
 	}
 
 	// Parse the response
@@ -57,6 +55,7 @@ func uploadSocialMediaLink(ctx context.Context, client *httpClient, options Uplo
 		return nil, &SDKError{
 			Message: fmt.Sprintf("Invalid response from API: %v", err),
 			Code:    ErrorCodeServerError,
+			Err:     err,
 		}
 	}
 	if response.RequestID == nil {
@@ -267,7 +267,8 @@ var _ = Describe("Social Media Upload", func() {
 				var sdkErr *realitydefender.SDKError
 				Expect(err).To(BeAssignableToTypeOf(sdkErr))
 				sdkErr = err.(*realitydefender.SDKError)
-				Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeUploadFailed))
+				Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeServerError))
+				Expect(sdkErr.StatusCode).To(Equal(http.StatusInternalServerError))
 			})
 		})
 
@@ -326,7 +327,8 @@ var _ = Describe("Social Media Upload", func() {
 				var sdkErr *realitydefender.SDKError
 				Expect(err).To(BeAssignableToTypeOf(sdkErr))
 				sdkErr = err.(*realitydefender.SDKError)
-				Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeUploadFailed))
+				Expect(sdkErr.Code).To(Equal(realitydefender.ErrorCodeServerError))
+				Expect(sdkErr.Unwrap()).NotTo(BeNil())
 			})
 		})
 
@@ -491,12 +493,12 @@ var _ = Describe("Social Media Upload", func() {
 					sdkErr = err.(*realitydefender.SDKError)
 					Expect(sdkErr.Code).To(Equal(expectedErrorCode))
 				},
-				Entry("400 Bad Request", http.StatusBadRequest, `{"code":"bad-request","response":"Invalid URL"}`, realitydefender.ErrorCodeUploadFailed, "Social media link upload failed:"),
-				Entry("401 Unauthorized", http.StatusUnauthorized, `{"error":"Unauthorized"}`, realitydefender.ErrorCodeUploadFailed, "Social media link upload failed:"),
-				Entry("403 Forbidden", http.StatusForbidden, `{"error":"Forbidden"}`, realitydefender.ErrorCodeUploadFailed, "Social media link upload failed:"),
-				Entry("404 Not Found", http.StatusNotFound, `{"error":"Not found"}`, realitydefender.ErrorCodeUploadFailed, "Social media link upload failed:"),
-				Entry("429 Too Many Requests", http.StatusTooManyRequests, `{"error":"Rate limited"}`, realitydefender.ErrorCodeUploadFailed, "Social media link upload failed:"),
-				Entry("500 Internal Server Error", http.StatusInternalServerError, `{"error":"Server error"}`, realitydefender.ErrorCodeUploadFailed, "Social media link upload failed:"),
+				Entry("400 Bad Request", http.StatusBadRequest, `{"code":"bad-request","response":"Invalid URL"}`, realitydefender.ErrorCodeInvalidRequest, "Social media link upload failed:"),
+				Entry("401 Unauthorized", http.StatusUnauthorized, `{"error":"Unauthorized"}`, realitydefender.ErrorCodeUnauthorized, "Social media link upload failed:"),
+				Entry("403 Forbidden", http.StatusForbidden, `{"error":"Forbidden"}`, realitydefender.ErrorCodeServerError, "Social media link upload failed:"),
+				Entry("404 Not Found", http.StatusNotFound, `{"error":"Not found"}`, realitydefender.ErrorCodeNotFound, "Social media link upload failed:"),
+				Entry("429 Too Many Requests", http.StatusTooManyRequests, `{"error":"Rate limited"}`, realitydefender.ErrorCodeRateLimited, "Social media link upload failed:"),
+				Entry("500 Internal Server Error", http.StatusInternalServerError, `{"error":"Server error"}`, realitydefender.ErrorCodeServerError, "Social media link upload failed:"),
 			)
 		})
 	})
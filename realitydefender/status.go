@@ -0,0 +1,120 @@
+package realitydefender
+
+// Status is the detection status of a result or model. Values the SDK does not know are
+// kept as the API returned them.
+type Status string
+
+// Detection statuses
+const (
+	// StatusAnalyzing means the media is still being analyzed
+	StatusAnalyzing Status = "ANALYZING"
+	// StatusAuthentic means no manipulation was detected
+	StatusAuthentic Status = "AUTHENTIC"
+	// StatusManipulated means manipulation was detected. The API reports it as FAKE.
+	StatusManipulated Status = "MANIPULATED"
+	// StatusSuspicious means signs of manipulation were found without a firm determination
+	StatusSuspicious Status = "SUSPICIOUS"
+	// StatusNotApplicable means a model does not apply to the media
+	StatusNotApplicable Status = "NOT_APPLICABLE"
+	// StatusUnableToEvaluate means the media could not be analyzed
+	StatusUnableToEvaluate Status = "UNABLE_TO_EVALUATE"
+	// StatusError means the analysis failed
+	StatusError Status = "ERROR"
+)
+
+// statusFake is how the API reports StatusManipulated
+const statusFake = "FAKE"
+
+// ParseStatus converts a status as the API reports it, mapping FAKE to StatusManipulated.
+// Unknown values are kept unchanged.
+func ParseStatus(value string) Status {
+	if value == statusFake {
+		return StatusManipulated
+	}
+	return Status(value)
+}
+
+// String implements fmt.Stringer
+func (s Status) String() string {
+	return string(s)
+}
+
+// Known reports whether the status is one of the Status constants
+func (s Status) Known() bool {
+	switch s {
+	case StatusAnalyzing, StatusAuthentic, StatusManipulated, StatusSuspicious, StatusNotApplicable, StatusUnableToEvaluate, StatusError:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether analysis has finished
+func (s Status) IsFinal() bool {
+	return s != StatusAnalyzing
+}
+
+// IsManipulated reports whether manipulation was detected
+func (s Status) IsManipulated() bool {
+	return s == StatusManipulated
+}
+
+// IsAuthentic reports whether the media was found authentic
+func (s Status) IsAuthentic() bool {
+	return s == StatusAuthentic
+}
+
+// MarshalText implements encoding.TextMarshaler, which JSON encoding uses
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, which JSON decoding uses. FAKE is read
+// as StatusManipulated and unknown values are kept.
+func (s *Status) UnmarshalText(text []byte) error {
+	*s = ParseStatus(string(text))
+	return nil
+}
+
+// IsFinal reports whether analysis has finished: the status is not ANALYZING, and the models
+// are not all either ANALYZING or NOT_APPLICABLE with at least one ANALYZING
+func (r DetectionResult) IsFinal() bool {
+	if r.Status == StatusAnalyzing {
+		return false
+	}
+
+	hasAnalyzingModels := false
+	for _, model := range r.Models {
+		if model.Status != StatusAnalyzing && model.Status != StatusNotApplicable {
+			return true
+		}
+		if model.Status == StatusAnalyzing {
+			hasAnalyzingModels = true
+		}
+	}
+	return !hasAnalyzingModels
+}
+
+// IsManipulated reports whether manipulation was detected in the media
+func (r DetectionResult) IsManipulated() bool {
+	return r.Status.IsManipulated()
+}
+
+// IsAuthentic reports whether the media was found authentic
+func (r DetectionResult) IsAuthentic() bool {
+	return r.Status.IsAuthentic()
+}
+
+// IsFinal reports whether the model has finished its analysis
+func (m ModelResult) IsFinal() bool {
+	return m.Status.IsFinal()
+}
+
+// IsManipulated reports whether the model detected manipulation
+func (m ModelResult) IsManipulated() bool {
+	return m.Status.IsManipulated()
+}
+
+// IsAuthentic reports whether the model found the media authentic
+func (m ModelResult) IsAuthentic() bool {
+	return m.Status.IsAuthentic()
+}
@@ -0,0 +1,65 @@
+package realitydefender_test
+
+import (
+	"encoding/json"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Status", func() {
+	It("maps FAKE to MANIPULATED and keeps unknown values", func() {
+		Expect(realitydefender.ParseStatus("FAKE")).To(Equal(realitydefender.StatusManipulated))
+		Expect(realitydefender.ParseStatus("AUTHENTIC")).To(Equal(realitydefender.StatusAuthentic))
+		Expect(realitydefender.ParseStatus("NEW_STATUS")).To(Equal(realitydefender.Status("NEW_STATUS")))
+		Expect(realitydefender.Status("NEW_STATUS").Known()).To(BeFalse())
+		Expect(realitydefender.StatusUnableToEvaluate.Known()).To(BeTrue())
+	})
+
+	It("marshals to and from JSON", func() {
+		var result realitydefender.DetectionResult
+		Expect(json.Unmarshal([]byte(`{"requestId":"req-1","status":"FAKE","models":[{"name":"m","status":"NEW_STATUS"}]}`), &result)).To(Succeed())
+		Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(result.Models[0].Status).To(Equal(realitydefender.Status("NEW_STATUS")))
+
+		data, err := json.Marshal(result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"status":"MANIPULATED"`))
+		Expect(string(data)).To(ContainSubstring(`"status":"NEW_STATUS"`))
+
+		counts, err := json.Marshal(map[realitydefender.Status]int{realitydefender.StatusAuthentic: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(counts)).To(Equal(`{"AUTHENTIC":2}`))
+	})
+
+	DescribeTable("predicates on a result",
+		func(status realitydefender.Status, models []realitydefender.Status, final, manipulated, authentic bool) {
+			result := realitydefender.DetectionResult{Status: status}
+			for _, model := range models {
+				result.Models = append(result.Models, realitydefender.ModelResult{Name: "m", Status: model})
+			}
+			Expect(result.IsFinal()).To(Equal(final))
+			Expect(result.IsManipulated()).To(Equal(manipulated))
+			Expect(result.IsAuthentic()).To(Equal(authentic))
+		},
+		Entry("analyzing", realitydefender.StatusAnalyzing, nil, false, false, false),
+		Entry("manipulated", realitydefender.StatusManipulated, []realitydefender.Status{realitydefender.StatusManipulated}, true, true, false),
+		Entry("authentic", realitydefender.StatusAuthentic, nil, true, false, true),
+		Entry("models still analyzing", realitydefender.StatusAuthentic,
+			[]realitydefender.Status{realitydefender.StatusAnalyzing, realitydefender.StatusNotApplicable}, false, false, true),
+		Entry("models not applicable", realitydefender.StatusUnableToEvaluate,
+			[]realitydefender.Status{realitydefender.StatusNotApplicable}, true, false, false),
+		Entry("one model done", realitydefender.StatusSuspicious,
+			[]realitydefender.Status{realitydefender.StatusAnalyzing, realitydefender.StatusSuspicious}, true, false, false),
+	)
+
+	It("has predicates on models", func() {
+		model := realitydefender.ModelResult{Status: realitydefender.StatusAnalyzing}
+		Expect(model.IsFinal()).To(BeFalse())
+		model.Status = realitydefender.StatusManipulated
+		Expect(model.IsFinal()).To(BeTrue())
+		Expect(model.IsManipulated()).To(BeTrue())
+		Expect(model.IsAuthentic()).To(BeFalse())
+	})
+})
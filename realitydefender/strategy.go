@@ -0,0 +1,165 @@
+package realitydefender
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Defaults for ExponentialPoll
+const (
+	DefaultPollMultiplier  = 2.0
+	DefaultPollMaxInterval = 30 * time.Second
+)
+
+// MediaType is the kind of media a request analyzes
+type MediaType string
+
+// Media types, by the supported file extensions
+const (
+	MediaTypeUnknown MediaType = ""
+	MediaTypeImage   MediaType = "image"
+	MediaTypeVideo   MediaType = "video"
+	MediaTypeAudio   MediaType = "audio"
+	MediaTypeText    MediaType = "text"
+)
+
+// mediaTypes maps the supported extensions to their media type
+var mediaTypes = map[string]MediaType{
+	".mp4": MediaTypeVideo, ".mov": MediaTypeVideo,
+	".jpg": MediaTypeImage, ".png": MediaTypeImage, ".jpeg": MediaTypeImage, ".gif": MediaTypeImage, ".webp": MediaTypeImage,
+	".flac": MediaTypeAudio, ".wav": MediaTypeAudio, ".mp3": MediaTypeAudio, ".m4a": MediaTypeAudio,
+	".aac": MediaTypeAudio, ".alac": MediaTypeAudio, ".ogg": MediaTypeAudio,
+	".txt": MediaTypeText,
+}
+
+// MediaTypeOf returns the media type of a file name by its extension, or MediaTypeUnknown
+func MediaTypeOf(fileName string) MediaType {
+	return mediaTypes[strings.ToLower(filepath.Ext(fileName))]
+}
+
+// PollState describes the polling of a request when the wait before the next poll is chosen
+type PollState struct {
+	// RequestID is the request being polled
+	RequestID string
+	// MediaType is the kind of media analyzed, MediaTypeUnknown when not known
+	MediaType MediaType
+	// Attempt is the number of polls made so far, starting at 1
+	Attempt int
+	// Elapsed is the time since the first poll
+	Elapsed time.Duration
+}
+
+// PollStrategy decides how long to wait between polls for a result. Implementations must be
+// safe for concurrent use, since one strategy may schedule many requests.
+type PollStrategy interface {
+	// NextDelay returns the wait before the next poll
+	NextDelay(state PollState) time.Duration
+}
+
+// ConstantPoll waits the same interval before every poll
+type ConstantPoll struct {
+	// Interval is the wait between polls (defaults to DefaultPollingInterval)
+	Interval time.Duration
+}
+
+// NextDelay implements PollStrategy
+func (s ConstantPoll) NextDelay(PollState) time.Duration {
+	if s.Interval <= 0 {
+		return time.Duration(DefaultPollingInterval) * time.Millisecond
+	}
+	return s.Interval
+}
+
+// ExponentialPoll waits Initial after the first poll, multiplying the wait by Multiplier
+// after each further poll, up to Max
+type ExponentialPoll struct {
+	// Initial is the wait after the first poll (defaults to DefaultPollingInterval)
+	Initial time.Duration
+	// Multiplier is the growth of the wait per poll (defaults to DefaultPollMultiplier)
+	Multiplier float64
+	// Max caps the wait (defaults to DefaultPollMaxInterval)
+	Max time.Duration
+}
+
+// NextDelay implements PollStrategy
+func (s ExponentialPoll) NextDelay(state PollState) time.Duration {
+	initial := s.Initial
+	if initial <= 0 {
+		initial = time.Duration(DefaultPollingInterval) * time.Millisecond
+	}
+	multiplier := s.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultPollMultiplier
+	}
+	maximum := s.Max
+	if maximum <= 0 {
+		maximum = DefaultPollMaxInterval
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(max(state.Attempt-1, 0)))
+	if delay > float64(maximum) {
+		return maximum
+	}
+	return time.Duration(delay)
+}
+
+// MediaTypePoll picks a strategy by the media type of the request. Use
+// DefaultMediaTypePoll for schedules suited to the usual analysis times.
+type MediaTypePoll struct {
+	// Strategies holds the strategy of each media type
+	Strategies map[MediaType]PollStrategy
+	// Default is used for other and unknown media types (defaults to ConstantPoll{})
+	Default PollStrategy
+}
+
+// NextDelay implements PollStrategy
+func (s MediaTypePoll) NextDelay(state PollState) time.Duration {
+	if strategy, ok := s.Strategies[state.MediaType]; ok && strategy != nil {
+		return strategy.NextDelay(state)
+	}
+	if s.Default != nil {
+		return s.Default.NextDelay(state)
+	}
+	return ConstantPoll{}.NextDelay(state)
+}
+
+// DefaultMediaTypePoll returns a MediaTypePoll that polls images and text quickly, since
+// they finish within seconds, and backs off for audio and video, which take minutes
+func DefaultMediaTypePoll() MediaTypePoll {
+	return MediaTypePoll{
+		Strategies: map[MediaType]PollStrategy{
+			MediaTypeImage: ExponentialPoll{Initial: 500 * time.Millisecond, Multiplier: 1.5, Max: 4 * time.Second},
+			MediaTypeText:  ExponentialPoll{Initial: 500 * time.Millisecond, Multiplier: 1.5, Max: 4 * time.Second},
+			MediaTypeAudio: ExponentialPoll{Initial: 2 * time.Second, Multiplier: 1.5, Max: 15 * time.Second},
+			MediaTypeVideo: ExponentialPoll{Initial: 5 * time.Second, Multiplier: 1.5, Max: 30 * time.Second},
+		},
+		Default: ConstantPoll{},
+	}
+}
+
+// pollStrategy returns strategy, or a ConstantPoll of interval when it is nil. The interval
+// in milliseconds of the deprecated PollingInterval options is used when interval is unset.
+func pollStrategy(strategy PollStrategy, interval time.Duration, intervalMillis int) PollStrategy {
+	if strategy != nil {
+		return strategy
+	}
+	if interval <= 0 {
+		interval = time.Duration(intervalMillis) * time.Millisecond
+	}
+	return ConstantPoll{Interval: interval}
+}
+
+// pollTimeout returns the time PollOptions allow for polling, falling back to the deprecated
+// Timeout in milliseconds and then to DefaultTimeout
+func pollTimeout(options *PollOptions) time.Duration {
+	switch {
+	case options.PollTimeout > 0:
+		return options.PollTimeout
+	case options.Timeout > 0:
+		return time.Duration(options.Timeout) * time.Millisecond
+	default:
+		return time.Duration(DefaultTimeout) * time.Millisecond
+	}
+}
@@ -0,0 +1,197 @@
+package realitydefender_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// recordingStrategy waits a fixed delay and records the states it was asked about
+type recordingStrategy struct {
+	mu     sync.Mutex
+	delay  time.Duration
+	states []realitydefender.PollState
+}
+
+func (s *recordingStrategy) NextDelay(state realitydefender.PollState) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states = append(s.states, state)
+	return s.delay
+}
+
+func (s *recordingStrategy) recorded() []realitydefender.PollState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]realitydefender.PollState(nil), s.states...)
+}
+
+var _ = Describe("Poll strategies", func() {
+	Describe("ConstantPoll", func() {
+		It("waits the interval, defaulting to DefaultPollingInterval", func() {
+			Expect(realitydefender.ConstantPoll{Interval: time.Second}.NextDelay(realitydefender.PollState{Attempt: 7})).To(Equal(time.Second))
+			Expect(realitydefender.ConstantPoll{}.NextDelay(realitydefender.PollState{Attempt: 1})).To(Equal(2 * time.Second))
+		})
+	})
+
+	Describe("ExponentialPoll", func() {
+		It("grows the wait up to the maximum", func() {
+			strategy := realitydefender.ExponentialPoll{Initial: 100 * time.Millisecond, Multiplier: 3, Max: time.Second}
+
+			var delays []time.Duration
+			for attempt := 1; attempt <= 4; attempt++ {
+				delays = append(delays, strategy.NextDelay(realitydefender.PollState{Attempt: attempt}))
+			}
+			Expect(delays).To(Equal([]time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second}))
+		})
+
+		It("uses defaults for unset fields", func() {
+			strategy := realitydefender.ExponentialPoll{}
+			Expect(strategy.NextDelay(realitydefender.PollState{Attempt: 2})).To(Equal(4 * time.Second))
+			Expect(strategy.NextDelay(realitydefender.PollState{Attempt: 100})).To(Equal(realitydefender.DefaultPollMaxInterval))
+		})
+	})
+
+	Describe("MediaTypePoll", func() {
+		It("picks the strategy of the media type", func() {
+			strategy := realitydefender.MediaTypePoll{
+				Strategies: map[realitydefender.MediaType]realitydefender.PollStrategy{
+					realitydefender.MediaTypeImage: realitydefender.ConstantPoll{Interval: time.Second},
+				},
+				Default: realitydefender.ConstantPoll{Interval: time.Minute},
+			}
+			Expect(strategy.NextDelay(realitydefender.PollState{MediaType: realitydefender.MediaTypeImage})).To(Equal(time.Second))
+			Expect(strategy.NextDelay(realitydefender.PollState{MediaType: realitydefender.MediaTypeVideo})).To(Equal(time.Minute))
+			Expect(strategy.NextDelay(realitydefender.PollState{})).To(Equal(time.Minute))
+		})
+
+		It("polls images sooner than videos by default", func() {
+			strategy := realitydefender.DefaultMediaTypePoll()
+			image := strategy.NextDelay(realitydefender.PollState{MediaType: realitydefender.MediaTypeImage, Attempt: 1})
+			video := strategy.NextDelay(realitydefender.PollState{MediaType: realitydefender.MediaTypeVideo, Attempt: 1})
+			Expect(image).To(BeNumerically("<", video))
+		})
+	})
+
+	Describe("MediaTypeOf", func() {
+		It("maps extensions to media types", func() {
+			Expect(realitydefender.MediaTypeOf("clip.MOV")).To(Equal(realitydefender.MediaTypeVideo))
+			Expect(realitydefender.MediaTypeOf("/tmp/photo.jpeg")).To(Equal(realitydefender.MediaTypeImage))
+			Expect(realitydefender.MediaTypeOf("voice.ogg")).To(Equal(realitydefender.MediaTypeAudio))
+			Expect(realitydefender.MediaTypeOf("post.txt")).To(Equal(realitydefender.MediaTypeText))
+			Expect(realitydefender.MediaTypeOf("archive.zip")).To(Equal(realitydefender.MediaTypeUnknown))
+		})
+	})
+
+	Describe("polling", func() {
+		var (
+			server *realitydefendertest.Server
+			client *realitydefender.Client
+		)
+
+		BeforeEach(func() {
+			// Analyzing for two polls, then final
+			outcome := realitydefendertest.Outcome{AnalyzingPolls: 2, Status: "AUTHENTIC"}
+			server = newFakeServer()
+			server.SetDefaultOutcome(outcome)
+			server.AddResult("req-1", outcome)
+
+			var err error
+			client, err = server.NewClient(realitydefender.Config{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("asks the strategy of GetResult for each wait", func() {
+			strategy := &recordingStrategy{delay: time.Millisecond}
+			result, err := client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{
+				Strategy:  strategy,
+				MediaType: realitydefender.MediaTypeVideo,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+
+			states := strategy.recorded()
+			Expect(states).To(HaveLen(2))
+			Expect(states[0].RequestID).To(Equal("req-1"))
+			Expect(states[0].MediaType).To(Equal(realitydefender.MediaTypeVideo))
+			Expect(states[0].Attempt).To(Equal(1))
+			Expect(states[1].Attempt).To(Equal(2))
+			Expect(states[1].Elapsed).To(BeNumerically(">=", states[0].Elapsed))
+		})
+
+		It("takes the interval and timeout as durations", func() {
+			start := time.Now()
+			result, err := client.GetResult(context.Background(), "req-1", &realitydefender.GetResultOptions{
+				Interval:        time.Millisecond,
+				PollingInterval: 60000,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status).To(Equal(realitydefender.StatusAuthentic))
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+
+			server.AddResult("req-2", realitydefendertest.Outcome{AnalyzingPolls: 2, Status: "AUTHENTIC"})
+			err = client.PollForResults(context.Background(), "req-2", &realitydefender.PollOptions{
+				Interval:    10 * time.Millisecond,
+				PollTimeout: 5 * time.Millisecond,
+				Timeout:     60000,
+			})
+			Expect(err).To(MatchError(realitydefender.ErrTimeout))
+		})
+
+		It("passes the media type of the file from DetectBatch", func() {
+			dir := GinkgoT().TempDir()
+			path := filepath.Join(dir, "photo.png")
+			Expect(os.WriteFile(path, []byte("\x89PNG\r\n\x1a\n"), 0o600)).To(Succeed())
+
+			strategy := &recordingStrategy{delay: time.Millisecond}
+			summary := client.DetectBatch(context.Background(), []realitydefender.UploadOptions{{FilePath: path}}, realitydefender.BatchOptions{
+				ResultOptions: realitydefender.GetResultOptions{Strategy: strategy},
+			}).Wait()
+			Expect(summary.Succeeded).To(Equal(1))
+			Expect(strategy.recorded()[0].MediaType).To(Equal(realitydefender.MediaTypeImage))
+		})
+
+		It("passes the registered media type to the Poller's strategy", func() {
+			strategy := &recordingStrategy{delay: time.Millisecond}
+			poller := client.NewPoller(realitydefender.PollerOptions{Strategy: strategy, RequestsPerSecond: 1000})
+			defer poller.Close()
+
+			var completion realitydefender.PollCompletion
+			Eventually(poller.RegisterMediaChan("req-1", realitydefender.MediaTypeVideo)).Should(Receive(&completion))
+			Expect(completion.Err).NotTo(HaveOccurred())
+			Expect(strategy.recorded()).NotTo(BeEmpty())
+			Expect(strategy.recorded()[0].MediaType).To(Equal(realitydefender.MediaTypeVideo))
+		})
+
+		It("stops waiting between polls when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			strategy := &recordingStrategy{delay: time.Hour}
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- client.PollForResults(ctx, "req-1", &realitydefender.PollOptions{Strategy: strategy, Timeout: 1000})
+			}()
+			Eventually(strategy.recorded).ShouldNot(BeEmpty())
+			cancel()
+
+			Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+		})
+
+		It("paces Watch with the strategy", func() {
+			strategy := &recordingStrategy{delay: time.Millisecond}
+			var last realitydefender.PollUpdate
+			for update := range client.Watch(context.Background(), "req-1", &realitydefender.PollOptions{Strategy: strategy}) {
+				last = update
+			}
+			Expect(last.Final).To(BeTrue())
+			Expect(strategy.recorded()).To(HaveLen(2))
+		})
+	})
+})
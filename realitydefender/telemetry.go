@@ -0,0 +1,141 @@
+package realitydefender
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the SDK to OpenTelemetry providers
+const instrumentationName = "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+
+// telemetry holds the OpenTelemetry tracer, metric instruments and propagator used by a client
+type telemetry struct {
+	tracer        trace.Tracer
+	propagator    propagation.TextMapPropagator
+	duration      metric.Float64Histogram
+	retries       metric.Int64Counter
+	uploadedBytes metric.Int64Counter
+	detections    metric.Int64Counter
+}
+
+// newTelemetry creates instruments from the given providers, falling back to the global ones
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider, propagator propagation.TextMapPropagator) *telemetry {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+
+	// Instrument creation only fails on invalid names, which are constants here;
+	// the returned instruments are usable no-ops in that case
+	duration, _ := meter.Float64Histogram("realitydefender.client.request.duration",
+		metric.WithDescription("Duration of HTTP requests made by the SDK"),
+		metric.WithUnit("s"),
+	)
+	retries, _ := meter.Int64Counter("realitydefender.client.request.retries",
+		metric.WithDescription("Number of HTTP request retries"),
+		metric.WithUnit("{retry}"),
+	)
+	uploadedBytes, _ := meter.Int64Counter("realitydefender.client.upload.size",
+		metric.WithDescription("Bytes uploaded to presigned URLs"),
+		metric.WithUnit("By"),
+	)
+	detections, _ := meter.Int64Counter("realitydefender.client.detections",
+		metric.WithDescription("Final detection results by status"),
+		metric.WithUnit("{result}"),
+	)
+
+	return &telemetry{
+		tracer:        tracerProvider.Tracer(instrumentationName),
+		propagator:    propagator,
+		duration:      duration,
+		retries:       retries,
+		uploadedBytes: uploadedBytes,
+		detections:    detections,
+	}
+}
+
+// startSpan starts an internal span for an SDK operation
+func (t *telemetry) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, "realitydefender."+name, trace.WithAttributes(attrs...))
+}
+
+// startRequestSpan starts a client span for an HTTP request to an endpoint
+func (t *telemetry) startRequestSpan(ctx context.Context, endpoint, method string) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, "realitydefender.http."+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("realitydefender.endpoint", endpoint),
+			attribute.String("http.request.method", method),
+		),
+	)
+}
+
+// inject writes the trace context of ctx into the request headers
+func (t *telemetry) inject(ctx context.Context, req *http.Request) {
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// recordRequest records the duration of one request attempt
+func (t *telemetry) recordRequest(ctx context.Context, endpoint, method string, status int, elapsed time.Duration) {
+	t.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(
+		attribute.String("realitydefender.endpoint", endpoint),
+		attribute.String("http.request.method", method),
+		attribute.Int("http.response.status_code", status),
+	))
+}
+
+// recordRetry counts a retried request
+func (t *telemetry) recordRetry(ctx context.Context, endpoint string) {
+	t.retries.Add(ctx, 1, metric.WithAttributes(attribute.String("realitydefender.endpoint", endpoint)))
+}
+
+// recordDetection counts a final detection result
+func (t *telemetry) recordDetection(ctx context.Context, status string) {
+	t.detections.Add(ctx, 1, metric.WithAttributes(attribute.String("realitydefender.status", status)))
+}
+
+// endSpan records the error, if any, and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// attemptReader counts the bytes of one upload attempt. Its count becomes the current one
+// when the attempt starts reading, so bodies opened for attempts never sent are not counted.
+type attemptReader struct {
+	reader  io.Reader
+	count   atomic.Int64
+	started bool
+	current *atomic.Pointer[atomic.Int64]
+}
+
+// Read implements io.Reader
+func (r *attemptReader) Read(p []byte) (int, error) {
+	if !r.started {
+		r.started = true
+		r.current.Store(&r.count)
+	}
+	n, err := r.reader.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
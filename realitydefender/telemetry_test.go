@@ -0,0 +1,214 @@
+package realitydefender_test
+
+import (
+	"context"
+	"errors"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender/realitydefendertest"
+	"net/http"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var _ = Describe("Telemetry", func() {
+	var (
+		server *realitydefendertest.Server
+		client *realitydefender.Client
+		spans  *tracetest.SpanRecorder
+		reader *sdkmetric.ManualReader
+	)
+
+	BeforeEach(func() {
+		server = newFakeServer()
+		server.AddResult("test-request-id", realitydefendertest.Outcome{AnalyzingPolls: 1})
+
+		spans = tracetest.NewSpanRecorder()
+		reader = sdkmetric.NewManualReader()
+
+		var err error
+		client, err = server.NewClient(realitydefender.Config{
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+			Propagator:     propagation.TraceContext{},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	spanNames := func() []string {
+		var names []string
+		for _, span := range spans.Ended() {
+			names = append(names, span.Name())
+		}
+		return names
+	}
+
+	findSpan := func(name string) sdktrace.ReadOnlySpan {
+		for _, span := range spans.Ended() {
+			if span.Name() == name {
+				return span
+			}
+		}
+		return nil
+	}
+
+	attributeValue := func(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+		for _, attr := range span.Attributes() {
+			if string(attr.Key) == key {
+				return attr.Value
+			}
+		}
+		return attribute.Value{}
+	}
+
+	collect := func() map[string]metricdata.Aggregation {
+		var rm metricdata.ResourceMetrics
+		Expect(reader.Collect(context.Background(), &rm)).To(Succeed())
+		out := map[string]metricdata.Aggregation{}
+		for _, scope := range rm.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				out[m.Name] = m.Data
+			}
+		}
+		return out
+	}
+
+	It("traces uploads with the signed URL request, the PUT and the bytes sent", func() {
+		_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(spanNames()).To(ContainElements(
+			"realitydefender.Upload",
+			"realitydefender.http.signed_url",
+			"realitydefender.http.upload",
+		))
+
+		upload := findSpan("realitydefender.Upload")
+		Expect(attributeValue(upload, "realitydefender.upload.bytes_sent").AsInt64()).To(Equal(int64(7)))
+		Expect(attributeValue(upload, "realitydefender.request_id").AsString()).To(Equal("request-1"))
+
+		put := findSpan("realitydefender.http.upload")
+		Expect(put.Parent().SpanID()).To(Equal(upload.SpanContext().SpanID()))
+
+		metrics := collect()
+		Expect(metrics).To(HaveKey("realitydefender.client.request.duration"))
+		uploaded := metrics["realitydefender.client.upload.size"].(metricdata.Sum[int64])
+		Expect(uploaded.DataPoints[0].Value).To(Equal(int64(7)))
+	})
+
+	It("reports the bytes of the last attempt that was sent", func() {
+		server.FailNext(realitydefendertest.EndpointUpload, http.StatusServiceUnavailable, 1)
+		// The retry's body is opened, then the rate limiter gives up before it is sent
+		limiter := &failingLimiter{failAt: 3}
+		retrying, err := server.NewClient(realitydefender.Config{
+			RetryPolicy:    &realitydefender.RetryPolicy{MaxAttempts: 2},
+			RateLimiter:    limiter,
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = retrying.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).To(HaveOccurred())
+
+		upload := findSpan("realitydefender.Upload")
+		Expect(attributeValue(upload, "realitydefender.upload.bytes_sent").AsInt64()).To(Equal(int64(7)))
+	})
+
+	It("propagates trace context in request headers", func() {
+		_, err := client.Upload(context.Background(), realitydefender.UploadOptions{
+			FileName: "clip.txt",
+			Data:     []byte("content"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		requests := server.Requests()
+		Expect(requests).To(HaveLen(2))
+		for _, request := range requests {
+			Expect(request.Header.Get("traceparent")).To(HavePrefix("00-"))
+		}
+	})
+
+	It("creates a span per poll and counts final statuses", func() {
+		result, err := client.GetResult(context.Background(), "test-request-id", &realitydefender.GetResultOptions{
+			PollingInterval: 10,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(realitydefender.StatusManipulated))
+
+		var polls []sdktrace.ReadOnlySpan
+		for _, span := range spans.Ended() {
+			if span.Name() == "realitydefender.poll" {
+				polls = append(polls, span)
+			}
+		}
+		Expect(polls).To(HaveLen(2))
+		Expect(attributeValue(polls[0], "realitydefender.status").AsString()).To(Equal("ANALYZING"))
+		Expect(attributeValue(polls[1], "realitydefender.poll.attempt").AsInt64()).To(Equal(int64(2)))
+		Expect(findSpan("realitydefender.GetResult")).NotTo(BeNil())
+
+		detections := collect()["realitydefender.client.detections"].(metricdata.Sum[int64])
+		Expect(detections.DataPoints).To(HaveLen(1))
+		status, _ := detections.DataPoints[0].Attributes.Value("realitydefender.status")
+		Expect(status.AsString()).To(Equal("MANIPULATED"))
+	})
+
+	It("counts final statuses found by PollForResults and Watch", func() {
+		Expect(client.PollForResults(context.Background(), "test-request-id", &realitydefender.PollOptions{
+			PollingInterval: 10,
+			Timeout:         10000,
+		})).To(Succeed())
+
+		server.AddResult("other-request-id", realitydefendertest.Outcome{})
+		for range client.Watch(context.Background(), "other-request-id", &realitydefender.PollOptions{PollingInterval: 10}) {
+		}
+
+		detections := collect()["realitydefender.client.detections"].(metricdata.Sum[int64])
+		Expect(detections.DataPoints).To(HaveLen(1))
+		Expect(detections.DataPoints[0].Value).To(Equal(int64(2)))
+	})
+
+	It("traces result pages and feedback", func() {
+		page := 0
+		_, err := client.GetResults(context.Background(), &page, nil, nil, nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.CreateUserFeedback(context.Background(), realitydefender.CreateUserFeedbackOptions{
+			RequestID:        "test-request-id",
+			Label:            "REAL",
+			FeedbackCategory: "CONFIRMATION",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		pages := findSpan("realitydefender.GetResults")
+		Expect(pages).NotTo(BeNil())
+		Expect(attributeValue(pages, "realitydefender.page.items").AsInt64()).To(Equal(int64(1)))
+		Expect(spanNames()).To(ContainElement("realitydefender.CreateUserFeedback"))
+	})
+})
+
+// failingLimiter allows requests until its failAt-th wait
+type failingLimiter struct {
+	waits  int32
+	failAt int32
+}
+
+func (l *failingLimiter) Wait(ctx context.Context) error {
+	if atomic.AddInt32(&l.waits, 1) >= l.failAt {
+		return errors.New("rate limit budget exhausted")
+	}
+	return nil
+}
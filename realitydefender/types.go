@@ -1,9 +1,36 @@
 package realitydefender
 
-// UploadOptions represents options for uploading media
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// UploadOptions represents options for uploading media.
+// Exactly one source must be set: FilePath (optionally within FS), Data or Reader.
 type UploadOptions struct {
 	// FilePath is the path to the file to be analyzed
 	FilePath string
+	// FS is an optional filesystem that FilePath is resolved against instead of the local disk
+	FS fs.FS
+	// Data is in-memory media content to be analyzed; FileName is required
+	Data []byte
+	// Reader is a media stream to be analyzed; FileName is required
+	Reader io.Reader
+	// Size is the number of bytes to read from Reader. It can be omitted for readers that
+	// report their own length (bytes.Reader, strings.Reader, os.File)
+	Size int64
+	// FileName names Data or Reader content; its extension selects the file type and size limit
+	FileName string
+	// RejectTypeMismatch rejects media whose content does not match its file extension.
+	// Without it the detected type silently takes precedence over the extension
+	RejectTypeMismatch bool
+	// BypassCache skips the result cache lookup and always uploads; the new upload is still cached
+	BypassCache bool
+	// OnProgress is called while the content is uploaded, at most every 100ms and once when
+	// all bytes are sent. It runs on the upload's goroutine and should return quickly.
+	OnProgress func(UploadProgress)
 }
 
 // UploadSocialMediaOptions represents options for uploading social media
@@ -24,16 +51,40 @@ type UploadResult struct {
 type GetResultOptions struct {
 	// MaxAttempts is the maximum number of polling attempts before returning even if still analyzing
 	MaxAttempts int
-	// PollingInterval is the interval in milliseconds between polling attempts
+	// Interval is the time between polling attempts, used when Strategy is nil
+	// (defaults to DefaultPollingInterval)
+	Interval time.Duration
+	// PollingInterval is the interval in milliseconds between polling attempts, used when
+	// Strategy and Interval are unset.
+	//
+	// Deprecated: Use Interval.
 	PollingInterval int
+	// Strategy decides the wait between polling attempts (defaults to a ConstantPoll of Interval)
+	Strategy PollStrategy
+	// MediaType is the kind of media analyzed, passed to Strategy
+	MediaType MediaType
 }
 
 // PollOptions represents options for polling for results
 type PollOptions struct {
-	// PollingInterval is the interval in milliseconds between polling attempts
+	// Interval is the time between polling attempts, used when Strategy is nil
+	// (defaults to DefaultPollingInterval)
+	Interval time.Duration
+	// PollTimeout is the maximum time to poll (defaults to DefaultTimeout)
+	PollTimeout time.Duration
+	// PollingInterval is the interval in milliseconds between polling attempts, used when
+	// Strategy and Interval are unset.
+	//
+	// Deprecated: Use Interval.
 	PollingInterval int
-	// Timeout is the maximum time to poll in milliseconds
+	// Timeout is the maximum time to poll in milliseconds, used when PollTimeout is unset.
+	//
+	// Deprecated: Use PollTimeout.
 	Timeout int
+	// Strategy decides the wait between polling attempts (defaults to a ConstantPoll of Interval)
+	Strategy PollStrategy
+	// MediaType is the kind of media analyzed, passed to Strategy
+	MediaType MediaType
 }
 
 // ModelResult represents results from an individual detection model
@@ -41,17 +92,21 @@ type ModelResult struct {
 	// Name is the model name
 	Name string `json:"name"`
 	// Status is the model status determination
-	Status string `json:"status"`
+	Status Status `json:"status"`
 	// Score is the model confidence score (0-1, nil if not available)
 	Score *float64 `json:"score"`
+	// Code is the code the API reported for the model, such as an error code
+	Code string `json:"code,omitempty"`
+	// Data is the model's raw details, decoded by Details and DecodeData
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
 // DetectionResult represents the simplified detection result returned to the user
 type DetectionResult struct {
 	// RequestID is the request ID that initiated the detection process
 	RequestID string `json:"requestId"`
-	// Status is the overall status determination (e.g., "MANIPULATED", "AUTHENTIC")
-	Status string `json:"status"`
+	// Status is the overall status determination (e.g., StatusManipulated, StatusAuthentic)
+	Status Status `json:"status"`
 	// Score is the confidence score (0-1, nil if processing)
 	Score *float64 `json:"score"`
 	// Models contains results from individual detection models
@@ -1,10 +1,16 @@
 package realitydefender_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
 )
 
 var _ = Describe("Types and Structures", func() {
@@ -20,6 +26,61 @@ var _ = Describe("Types and Structures", func() {
 			Expect(errString).To(ContainSubstring("invalid_file"))
 			Expect(errString).To(ContainSubstring("Code:"))
 		})
+
+		It("matches sentinel errors by code", func() {
+			err := fmt.Errorf("lookup: %w", &realitydefender.SDKError{
+				Message: "Resource not found",
+				Code:    realitydefender.ErrorCodeNotFound,
+			})
+
+			Expect(errors.Is(err, realitydefender.ErrNotFound)).To(BeTrue())
+			Expect(errors.Is(err, realitydefender.ErrUnauthorized)).To(BeFalse())
+
+			var sdkErr *realitydefender.SDKError
+			Expect(errors.As(err, &sdkErr)).To(BeTrue())
+			Expect(sdkErr.Message).To(Equal("Resource not found"))
+		})
+
+		It("unwraps to the underlying cause", func() {
+			err := &realitydefender.SDKError{
+				Message: "request failed: context deadline exceeded",
+				Code:    realitydefender.ErrorCodeTimeout,
+				Err:     context.DeadlineExceeded,
+			}
+
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(errors.Is(err, realitydefender.ErrTimeout)).To(BeTrue())
+		})
+
+		It("wraps the causes of file and decoding errors", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"requestId":`))
+			}))
+			defer server.Close()
+			client, err := realitydefender.New(realitydefender.Config{APIKey: "test-api-key", BaseURL: server.URL})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Upload(context.Background(), realitydefender.UploadOptions{FilePath: "missing.jpg"})
+			Expect(errors.Is(err, fs.ErrNotExist)).To(BeTrue())
+
+			_, err = client.GetResult(context.Background(), "test-request-id", nil)
+			var syntaxErr *json.SyntaxError
+			Expect(errors.As(err, &syntaxErr)).To(BeTrue())
+		})
+
+		DescribeTable("reports whether errors are retryable",
+			func(err *realitydefender.SDKError, retryable bool) {
+				Expect(err.Retryable()).To(Equal(retryable))
+			},
+			Entry("rate limited", &realitydefender.SDKError{Code: realitydefender.ErrorCodeRateLimited, StatusCode: 429}, true),
+			Entry("bad gateway", &realitydefender.SDKError{Code: realitydefender.ErrorCodeServerError, StatusCode: 502}, true),
+			Entry("internal server error", &realitydefender.SDKError{Code: realitydefender.ErrorCodeServerError, StatusCode: 500}, false),
+			Entry("timeout", &realitydefender.SDKError{Code: realitydefender.ErrorCodeTimeout, Err: context.DeadlineExceeded}, true),
+			Entry("canceled", &realitydefender.SDKError{Code: realitydefender.ErrorCodeTimeout, Err: context.Canceled}, false),
+			Entry("unauthorized", &realitydefender.SDKError{Code: realitydefender.ErrorCodeUnauthorized, StatusCode: 401}, false),
+			Entry("invalid file", &realitydefender.SDKError{Code: realitydefender.ErrorCodeInvalidFile}, false),
+		)
 	})
 
 	Describe("DetectionResult", func() {
@@ -324,21 +385,21 @@ var _ = Describe("Types and Structures", func() {
 
 			// Verify first item (completed)
 			Expect(result.Items[0].RequestID).To(Equal("test-request-id-1"))
-			Expect(result.Items[0].Status).To(Equal("MANIPULATED"))
+			Expect(result.Items[0].Status).To(Equal(realitydefender.StatusManipulated))
 			Expect(result.Items[0].Score).NotTo(BeNil())
 			Expect(*result.Items[0].Score).To(Equal(0.85))
 			Expect(result.Items[0].Models).To(HaveLen(1))
 
 			// Verify second item (processing)
 			Expect(result.Items[1].RequestID).To(Equal("test-request-id-2"))
-			Expect(result.Items[1].Status).To(Equal("ANALYZING"))
+			Expect(result.Items[1].Status).To(Equal(realitydefender.StatusAnalyzing))
 			Expect(result.Items[1].Score).To(BeNil())
 			Expect(result.Items[1].Models).To(HaveLen(1))
 			Expect(result.Items[1].Models[0].Score).To(BeNil())
 
 			// Verify third item (error)
 			Expect(result.Items[2].RequestID).To(Equal("test-request-id-3"))
-			Expect(result.Items[2].Status).To(Equal("ERROR"))
+			Expect(result.Items[2].Status).To(Equal(realitydefender.StatusError))
 			Expect(result.Items[2].Score).To(BeNil())
 			Expect(result.Items[2].Models).To(HaveLen(0))
 		})
@@ -441,7 +502,7 @@ var _ = Describe("Types and Structures", func() {
 			// Verify single item
 			Expect(result.Items).To(HaveLen(1))
 			Expect(result.Items[0].RequestID).To(Equal("test-request-id"))
-			Expect(result.Items[0].Status).To(Equal("AUTHENTIC"))
+			Expect(result.Items[0].Status).To(Equal(realitydefender.StatusAuthentic))
 			Expect(*result.Items[0].Score).To(Equal(0.75))
 			Expect(result.Items[0].Models).To(HaveLen(1))
 			Expect(result.Items[0].Models[0].Name).To(Equal("singleModel"))
@@ -0,0 +1,145 @@
+package realitydefender
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// PollUpdate is a state of a detection reported by Watch
+type PollUpdate struct {
+	// RequestID is the request being watched
+	RequestID string
+	// Attempt is the poll that produced the update
+	Attempt int
+	// Result is the detection as of this update, nil when Err is set before any result was
+	// found. Each poll decodes a new result, so it is not shared with other watches.
+	Result *DetectionResult
+	// Changed lists the models whose status changed since the previous update; on the first
+	// update it lists every model
+	Changed []ModelResult
+	// Final is set on the last update, which carries either the final result or Err
+	Final bool
+	// Err is the error that ended the watch
+	Err error
+}
+
+// Watch polls for the result of a request and streams its progress on the returned channel:
+// an update whenever the overall status or a model's status changes while analyzing, then a
+// final update with the result or error. The channel is closed after the final update.
+//
+// PollOptions default to a ConstantPoll of DefaultPollingInterval and DefaultTimeout; a
+// watch that times out ends with ErrTimeout. Cancelling ctx stops the watch and closes the
+// channel, delivering ctx's error only if the receiver has room for it.
+func (c *Client) Watch(ctx context.Context, requestID string, options *PollOptions) <-chan PollUpdate {
+	if options == nil {
+		options = &PollOptions{}
+	}
+	timeout := pollTimeout(options)
+
+	strategy := pollStrategy(options.Strategy, options.Interval, options.PollingInterval)
+	updates := make(chan PollUpdate, 1)
+	go func() {
+		defer close(updates)
+		c.watch(ctx, requestID, strategy, options.MediaType, timeout, updates)
+	}()
+	return updates
+}
+
+// watch runs the polling loop of Watch
+func (c *Client) watch(ctx context.Context, requestID string, strategy PollStrategy, mediaType MediaType, timeout time.Duration, updates chan<- PollUpdate) {
+	began := time.Now()
+	deadline := began.Add(timeout)
+	var (
+		previous *DetectionResult
+		attempt  int
+	)
+
+	// send delivers an update, giving up when ctx is cancelled
+	send := func(update PollUpdate) bool {
+		select {
+		case updates <- update:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// stop ends the watch with ctx's error, without waiting for the receiver
+	stop := func() {
+		select {
+		case updates <- PollUpdate{RequestID: requestID, Attempt: attempt, Result: previous, Final: true, Err: ctx.Err()}:
+		default:
+		}
+	}
+
+	for {
+		attempt++
+		result, err := c.pollOnce(ctx, requestID, attempt)
+
+		var sdkErr *SDKError
+		switch {
+		case ctx.Err() != nil:
+			stop()
+			return
+		case err != nil && !(errors.As(err, &sdkErr) && sdkErr.Code == ErrorCodeNotFound):
+			// Any error other than a result that is not ready yet ends the watch
+			send(PollUpdate{RequestID: requestID, Attempt: attempt, Result: previous, Final: true, Err: err})
+			return
+		case err == nil && result.IsFinal():
+			c.handleResult(ctx, result)
+			send(PollUpdate{RequestID: requestID, Attempt: attempt, Result: result, Changed: changedModels(previous, result), Final: true})
+			return
+		case err == nil:
+			changed := changedModels(previous, result)
+			if previous == nil || previous.Status != result.Status || len(changed) > 0 {
+				if !send(PollUpdate{RequestID: requestID, Attempt: attempt, Result: result, Changed: changed}) {
+					stop()
+					return
+				}
+			}
+			previous = result
+		}
+
+		delay := strategy.NextDelay(PollState{RequestID: requestID, MediaType: mediaType, Attempt: attempt, Elapsed: time.Since(began)})
+		if time.Now().Add(delay).After(deadline) {
+			c.httpClient.logger.WarnContext(ctx, "watch timed out", "request_id", requestID, "timeout", timeout)
+			send(PollUpdate{
+				RequestID: requestID,
+				Attempt:   attempt,
+				Result:    previous,
+				Final:     true,
+				Err: &SDKError{
+					Message: "Polling timeout exceeded",
+					Code:    ErrorCodeTimeout,
+				},
+			})
+			return
+		}
+
+		if sleepContext(ctx, delay) != nil {
+			stop()
+			return
+		}
+	}
+}
+
+// changedModels returns the models of current whose status differs from previous
+func changedModels(previous, current *DetectionResult) []ModelResult {
+	if previous == nil {
+		return append([]ModelResult(nil), current.Models...)
+	}
+
+	statuses := make(map[string]Status, len(previous.Models))
+	for _, model := range previous.Models {
+		statuses[model.Name] = model.Status
+	}
+
+	var changed []ModelResult
+	for _, model := range current.Models {
+		if status, ok := statuses[model.Name]; !ok || status != model.Status {
+			changed = append(changed, model)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,148 @@
+package realitydefender_test
+
+import (
+	"context"
+	realitydefender "github.com/Reality-Defender/realitydefender-sdk-go/realitydefender"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		server    *httptest.Server
+		client    *realitydefender.Client
+		mu        sync.Mutex
+		responses []string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			// Serve the responses in turn, repeating the last one
+			body := responses[0]
+			if len(responses) > 1 {
+				responses = responses[1:]
+			}
+			switch body {
+			case "":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":"not-found","response":"not found"}`))
+			case "unauthorized":
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":"unauthorized","response":"invalid key"}`))
+			default:
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(body))
+			}
+		}))
+
+		var err error
+		client, err = realitydefender.New(realitydefender.Config{
+			APIKey:  "test-api-key",
+			BaseURL: server.URL,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	serve := func(bodies ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+		responses = bodies
+	}
+
+	result := func(status, first, second string) string {
+		return `{"requestId":"req-1","resultsSummary":{"status":"` + status + `"},"models":[` +
+			`{"name":"first","status":"` + first + `"},{"name":"second","status":"` + second + `"}]}`
+	}
+
+	collect := func(updates <-chan realitydefender.PollUpdate) []realitydefender.PollUpdate {
+		var all []realitydefender.PollUpdate
+		for update := range updates {
+			all = append(all, update)
+		}
+		return all
+	}
+
+	It("streams model status changes and closes after the final result", func() {
+		serve(
+			"",
+			result("ANALYZING", "ANALYZING", "ANALYZING"),
+			result("ANALYZING", "ANALYZING", "ANALYZING"),
+			result("ANALYZING", "FAKE", "ANALYZING"),
+			result("FAKE", "FAKE", "AUTHENTIC"),
+		)
+
+		updates := collect(client.Watch(context.Background(), "req-1", &realitydefender.PollOptions{PollingInterval: 1, Timeout: 5000}))
+
+		Expect(updates).To(HaveLen(3))
+		Expect(updates[0].Attempt).To(Equal(2))
+		Expect(updates[0].Result.Status).To(Equal(realitydefender.StatusAnalyzing))
+		Expect(updates[0].Changed).To(HaveLen(2))
+		Expect(updates[0].Final).To(BeFalse())
+
+		Expect(updates[1].Attempt).To(Equal(4))
+		Expect(updates[1].Changed).To(HaveLen(1))
+		Expect(updates[1].Changed[0].Name).To(Equal("first"))
+		Expect(updates[1].Changed[0].Status).To(Equal(realitydefender.StatusManipulated))
+
+		Expect(updates[2].Final).To(BeTrue())
+		Expect(updates[2].Err).NotTo(HaveOccurred())
+		Expect(updates[2].Result.Status).To(Equal(realitydefender.StatusManipulated))
+		Expect(updates[2].Changed).To(HaveLen(1))
+		Expect(updates[2].Changed[0].Name).To(Equal("second"))
+	})
+
+	It("ends with the error that stopped polling", func() {
+		serve(result("ANALYZING", "ANALYZING", "ANALYZING"), "unauthorized")
+
+		updates := collect(client.Watch(context.Background(), "req-1", &realitydefender.PollOptions{PollingInterval: 1, Timeout: 5000}))
+
+		Expect(updates).To(HaveLen(2))
+		Expect(updates[1].Final).To(BeTrue())
+		Expect(updates[1].Err).To(MatchError(realitydefender.ErrUnauthorized))
+		Expect(updates[1].Result).To(Equal(updates[0].Result))
+	})
+
+	It("ends with a timeout error while still analyzing", func() {
+		serve(result("ANALYZING", "ANALYZING", "ANALYZING"))
+
+		updates := collect(client.Watch(context.Background(), "req-1", &realitydefender.PollOptions{PollingInterval: 10, Timeout: 50}))
+
+		last := updates[len(updates)-1]
+		Expect(last.Final).To(BeTrue())
+		Expect(last.Err).To(MatchError(realitydefender.ErrTimeout))
+		Expect(last.Result.Status).To(Equal(realitydefender.StatusAnalyzing))
+	})
+
+	It("closes the channel when the context is cancelled", func() {
+		serve("")
+		ctx, cancel := context.WithCancel(context.Background())
+		updates := client.Watch(ctx, "req-1", &realitydefender.PollOptions{PollingInterval: 1, Timeout: 60000})
+		cancel()
+
+		Eventually(updates).Should(BeClosed())
+	})
+
+	It("keeps concurrent watches apart", func() {
+		serve(result("AUTHENTIC", "AUTHENTIC", "AUTHENTIC"))
+
+		first := client.Watch(context.Background(), "req-1", nil)
+		second := client.Watch(context.Background(), "req-1", nil)
+		a, b := collect(first), collect(second)
+
+		Expect(a).To(HaveLen(1))
+		Expect(b).To(HaveLen(1))
+		Expect(a[0].Result).To(Equal(b[0].Result))
+		Expect(a[0].Result).NotTo(BeIdenticalTo(b[0].Result))
+	})
+})